@@ -2,76 +2,408 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
-	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/memory"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/email"
 	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/hasher"
 	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/idgen"
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/notifier"
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/password"
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/secret"
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/token"
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/totp"
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/auth"
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/grpc/interceptors"
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/health"
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/jobs"
 	userservice "github.com/obsessed-gopher/micro-service-guide/internal/app/grpc/user_service"
+	httpuserservice "github.com/obsessed-gopher/micro-service-guide/internal/app/http/user_service"
 	"github.com/obsessed-gopher/micro-service-guide/internal/config"
+	"github.com/obsessed-gopher/micro-service-guide/internal/logger"
+	"github.com/obsessed-gopher/micro-service-guide/internal/metrics"
 	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
 	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
 )
 
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
 func main() {
 	configPath := flag.String("config", "config/local.yml", "path to config file")
 	flag.Parse()
 
 	cfg, err := config.Load(*configPath)
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		fatal(slog.Default(), "failed to load config", err)
 	}
 
-	log.Printf("Starting %s in %s mode", cfg.App.Name, cfg.App.Env)
+	log, err := logger.New(cfg.Log, os.Stderr)
+	if err != nil {
+		fatal(slog.Default(), "failed to init logger", err)
+	}
+
+	log = log.With(slog.String("app", cfg.App.Name), slog.String("env", cfg.App.Env))
+	slog.SetDefault(log)
+
+	// Подкоманды: user_service [-config=...] <command> [args...]
+	if flag.NArg() > 0 {
+		if err := runCommand(cfg, flag.Args()); err != nil {
+			fatal(log, "command failed", err, slog.String("command", flag.Arg(0)))
+		}
+		return
+	}
+
+	if cfg.Auth.SigningKey == "" {
+		fatal(log, "invalid config", errors.New("auth.signing_key is required"))
+	}
+
+	if cfg.Purge.Enabled && cfg.Purge.Retention <= 0 {
+		fatal(log, "invalid config", errors.New("purge.retention must be positive"))
+	}
+
+	if cfg.PasswordReset.TokenTTL <= 0 {
+		fatal(log, "invalid config", errors.New("password_reset.token_ttl must be positive"))
+	}
+
+	if cfg.Email.VerificationTTL <= 0 {
+		fatal(log, "invalid config", errors.New("email.verification_ttl must be positive"))
+	}
+
+	if cfg.MFA.Enabled && cfg.MFA.ChallengeTTL <= 0 {
+		fatal(log, "invalid config", errors.New("mfa.challenge_ttl must be positive"))
+	}
+
+	if cfg.LoginThrottle.Enabled && (cfg.LoginThrottle.Window <= 0 || cfg.LoginThrottle.BaseDelay <= 0) {
+		fatal(log, "invalid config", errors.New("login_throttle.window and login_throttle.base_delay must be positive"))
+	}
+
+	if cfg.Password.MinLength <= 0 {
+		fatal(log, "invalid config", errors.New("password.min_length must be positive"))
+	}
+
+	log.Info("starting service")
+
+	if err := run(cfg, log); err != nil {
+		fatal(log, "service failed", err)
+	}
+}
 
+// run собирает зависимости и обслуживает запросы до сигнала остановки или
+// ошибки любого из серверов. Хранилище закрывается при любом выходе из run.
+func run(cfg *config.Config, log *slog.Logger) error {
 	// Инициализация зависимостей
-	// В реальном проекте здесь будет подключение к БД:
-	// db, err := sql.Open("postgres", cfg.Database.DSN())
-	// userRepo := repository.NewPostgresUserRepository(db)
+	store, err := newStorage(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("init storage: %w", err)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.Error("failed to close storage", slog.Any("error", err))
+		}
+	}()
+
+	if cfg.Database.AutoMigrate {
+		if err := autoMigrate(store); err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+	}
+
+	passwordPolicy, err := newPasswordPolicy(cfg.Password)
+	if err != nil {
+		return fmt.Errorf("init password policy: %w", err)
+	}
+
+	userNotifier, err := newNotifier(cfg.Notifier, log)
+	if err != nil {
+		return fmt.Errorf("init notifier: %w", err)
+	}
+
+	passwordHasher, err := newPasswordHasher(cfg.PasswordHash)
+	if err != nil {
+		return fmt.Errorf("init password hasher: %w", err)
+	}
 
-	userRepo := memory.NewMemoryUserRepository()
-	passwordHasher := hasher.NewBcryptHasher(0)
 	idGenerator := idgen.NewUUIDGenerator()
+	tokenManager := token.NewJWTManager(cfg.Auth.SigningKey, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL)
+
+	// Метрики
+	userMetrics := metrics.NewUserMetrics()
+	rpcMetrics := metrics.NewRPCMetrics()
 
 	// Бизнес-логика
-	userUsecase := usecases.NewUserUsecase(userRepo, passwordHasher, idGenerator)
+	userOptions := []usecases.Option{
+		usecases.WithMetrics(userMetrics),
+		usecases.WithEmailNormalizer(email.NewNormalizer(cfg.Email.ProviderRules)),
+		usecases.WithPasswordPolicy(passwordPolicy),
+		usecases.WithNotifier(userNotifier),
+		usecases.WithPasswordReset(store.resets, usecases.PasswordResetSettings{
+			TokenTTL:    cfg.PasswordReset.TokenTTL,
+			MaxRequests: cfg.PasswordReset.MaxRequests,
+			Window:      cfg.PasswordReset.Window,
+		}),
+		usecases.WithEmailVerification(store.verifications, usecases.EmailVerificationSettings{
+			TokenTTL:            cfg.Email.VerificationTTL,
+			RequireVerification: cfg.Email.RequireVerification,
+			MaxRequests:         cfg.Email.VerificationMaxRequests,
+			Window:              cfg.Email.VerificationWindow,
+		}),
+		usecases.WithRoles(store.roles),
+	}
 
-	// gRPC сервер
-	server := userservice.NewServer(userUsecase)
+	if cfg.MFA.Enabled {
+		mfa, err := newMFA(cfg.MFA, store.challenges)
+		if err != nil {
+			return fmt.Errorf("init mfa: %w", err)
+		}
+
+		userOptions = append(userOptions, mfa)
+	}
+
+	if cfg.LoginThrottle.Enabled {
+		userOptions = append(userOptions, newLoginThrottle(cfg.LoginThrottle, store.attempts))
+	}
+
+	userUsecase := usecases.NewUserUsecase(
+		store.users, store.sessions, passwordHasher, idGenerator, tokenManager,
+		userOptions...,
+	)
+
+	orgUsecase := usecases.NewOrganizationUsecase(store.orgs, store.memberships, userUsecase, idGenerator)
+
+	// Проверка прав по access токену и ролям
+	authorizer := auth.NewAuthorizer(tokenManager, userUsecase)
+
+	policy := userservice.Policy()
+	policy[healthpb.Health_Check_FullMethodName] = auth.Rule{Public: true}
 
 	// gRPC сервер
-	grpcServer := grpc.NewServer()
+	server := userservice.NewServer(userUsecase, orgUsecase)
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptors.Logging(log, idGenerator),
+			interceptors.Metrics(rpcMetrics),
+			interceptors.Tenant(cfg.Tenancy.DefaultTenant, healthpb.Health_Check_FullMethodName),
+			interceptors.Auth(authorizer, policy),
+		),
+	)
 
 	pb.RegisterUserServiceServer(grpcServer, server)
 
+	// Health checking: NOT_SERVING, пока репозиторий не ответит на пинг
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	checker := health.NewChecker(
+		healthServer, store.pinger, cfg.Health.CheckInterval, cfg.Health.CheckTimeout,
+		pb.UserService_ServiceDesc.ServiceName,
+	)
+
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	go checker.Run(bgCtx)
+
+	// Очистка мягко удалённых пользователей
+	if cfg.Purge.Enabled {
+		go jobs.NewPurgeJob(userUsecase, cfg.Purge.Retention, cfg.Purge.Interval).Run(bgCtx)
+	}
+
 	if cfg.App.Debug {
 		reflection.Register(grpcServer)
 	}
 
 	listener, err := net.Listen("tcp", cfg.Server.GRPC.Addr())
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		return fmt.Errorf("listen: %w", err)
 	}
 
-	// Graceful shutdown
+	// HTTP/JSON шлюз
+	httpMux := http.NewServeMux()
+	httpMux.Handle("/", httpuserservice.NewServer(userUsecase, orgUsecase, authorizer, cfg.Tenancy.DefaultTenant).Handler())
+	httpMux.Handle("GET /health", checker)
+
+	httpServer := &http.Server{
+		Addr:              cfg.Server.HTTP.Addr(),
+		Handler:           httpMux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	// Ошибки серверов передаются в run, чтобы отработали отложенные вызовы.
+	serveErrs := make(chan error, 3)
+
 	go func() {
-		sigCh := make(chan os.Signal, 1)
-		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-		<-sigCh
-		log.Println("Shutting down gRPC server...")
-		grpcServer.GracefulStop()
+		log.Info("HTTP server listening", slog.String("addr", cfg.Server.HTTP.Addr()))
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErrs <- fmt.Errorf("serve http: %w", err)
+		}
 	}()
 
-	log.Printf("gRPC server listening on %s", cfg.Server.GRPC.Addr())
-	if err := grpcServer.Serve(listener); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	// Prometheus метрики
+	var metricsServer *http.Server
+	if cfg.Metrics.Enabled {
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", metrics.NewHandler(userMetrics, rpcMetrics))
+
+		metricsServer = &http.Server{
+			Addr:              cfg.Metrics.Addr(),
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+
+		go func() {
+			log.Info("metrics server listening", slog.String("addr", cfg.Metrics.Addr()))
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serveErrs <- fmt.Errorf("serve metrics: %w", err)
+			}
+		}()
+	}
+
+	go func() {
+		log.Info("gRPC server listening", slog.String("addr", cfg.Server.GRPC.Addr()))
+		if err := grpcServer.Serve(listener); err != nil {
+			serveErrs <- fmt.Errorf("serve grpc: %w", err)
+		}
+	}()
+
+	// Graceful shutdown по сигналу или после ошибки одного из серверов
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	var serveErr error
+	select {
+	case <-sigCh:
+	case serveErr = <-serveErrs:
+	}
+
+	// Сначала снимаем под с балансировки, затем останавливаем серверы.
+	checker.Shutdown()
+	stopBackground()
+
+	log.Info("shutting down HTTP server")
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Error("failed to shutdown http server", slog.Any("error", err))
+	}
+	cancel()
+
+	log.Info("shutting down gRPC server")
+	grpcServer.GracefulStop()
+
+	// Метрики останавливаем последними.
+	if metricsServer != nil {
+		metricsServer.Close()
 	}
+
+	return serveErr
+}
+
+// newPasswordPolicy создаёт политику паролей из конфигурации.
+func newPasswordPolicy(cfg config.PasswordConfig) (*password.Policy, error) {
+	var breached []string
+
+	if cfg.BreachedList != "" {
+		list, err := password.LoadList(cfg.BreachedList)
+		if err != nil {
+			return nil, err
+		}
+
+		breached = list
+	}
+
+	return password.NewPolicy(password.Rules{
+		MinLength:          cfg.MinLength,
+		MaxLength:          cfg.MaxLength,
+		RequireLowercase:   cfg.RequireLowercase,
+		RequireUppercase:   cfg.RequireUppercase,
+		RequireDigit:       cfg.RequireDigit,
+		RequireSymbol:      cfg.RequireSymbol,
+		ForbidPersonalInfo: cfg.ForbidPersonalInfo,
+	}, breached), nil
+}
+
+// newPasswordHasher создаёт hasher с алгоритмом из password_hash.algorithm.
+// Хэши остальных алгоритмов продолжают проверяться и пересчитываются при входе.
+func newPasswordHasher(cfg config.PasswordHashConfig) (*hasher.MultiHasher, error) {
+	bcryptHasher := hasher.NewBcryptHasher(cfg.BcryptCost)
+	argon2Hasher := hasher.NewArgon2Hasher(hasher.Argon2Params{
+		Memory:      cfg.Argon2.Memory,
+		Iterations:  cfg.Argon2.Iterations,
+		Parallelism: cfg.Argon2.Parallelism,
+	})
+
+	switch cfg.Algorithm {
+	case config.HashBcrypt, "":
+		return hasher.NewMultiHasher(bcryptHasher, argon2Hasher), nil
+	case config.HashArgon2id:
+		return hasher.NewMultiHasher(argon2Hasher, bcryptHasher), nil
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", cfg.Algorithm)
+	}
+}
+
+// newMFA создаёт настройку двухфакторной аутентификации из конфигурации.
+func newMFA(cfg config.MFAConfig, challenges usecases.MFAChallengeRepository) (usecases.Option, error) {
+	cipher, err := secret.NewAESCipher(cfg.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("mfa.encryption_key: %w", err)
+	}
+
+	return usecases.WithMFA(totp.NewGenerator(cfg.Issuer), cipher, challenges, usecases.MFASettings{
+		ChallengeTTL:  cfg.ChallengeTTL,
+		MaxAttempts:   cfg.MaxAttempts,
+		RecoveryCodes: cfg.RecoveryCodes,
+	}), nil
+}
+
+// newLoginThrottle создаёт настройку защиты от перебора паролей из конфигурации.
+func newLoginThrottle(cfg config.LoginThrottleConfig, attempts usecases.LoginAttemptRepository) usecases.Option {
+	return usecases.WithLoginThrottle(attempts, usecases.LoginThrottleSettings{
+		Window:       cfg.Window,
+		BaseDelay:    cfg.BaseDelay,
+		MaxDelay:     cfg.MaxDelay,
+		LockDuration: cfg.LockDuration,
+		User:         usecases.LoginThrottleLimits{FreeAttempts: cfg.User.FreeAttempts, LockAfter: cfg.User.LockAfter},
+		IP:           usecases.LoginThrottleLimits{FreeAttempts: cfg.IP.FreeAttempts, LockAfter: cfg.IP.LockAfter},
+	})
+}
+
+// newNotifier создаёт доставку уведомлений в зависимости от notifier.driver.
+func newNotifier(cfg config.NotifierConfig, log *slog.Logger) (usecases.Notifier, error) {
+	switch cfg.Driver {
+	case config.NotifierLog, "":
+		return notifier.NewLogNotifier(log), nil
+	case config.NotifierFile:
+		if cfg.File == "" {
+			return nil, errors.New("notifier.file is required for file driver")
+		}
+
+		return notifier.NewFileNotifier(cfg.File), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier driver %q", cfg.Driver)
+	}
+}
+
+// fatal логирует ошибку и завершает процесс.
+func fatal(log *slog.Logger, msg string, err error, attrs ...any) {
+	log.Error(msg, append([]any{slog.Any("error", err)}, attrs...)...)
+	os.Exit(1)
 }
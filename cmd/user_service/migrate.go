@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/migrator"
+	"github.com/obsessed-gopher/micro-service-guide/internal/config"
+	"github.com/obsessed-gopher/micro-service-guide/migrations"
+)
+
+const migrateUsage = "usage: user_service migrate up | down [N] | status | force V"
+
+// runCommand выполняет подкоманду вместо запуска сервера.
+func runCommand(cfg *config.Config, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(cfg.Database, args[1:])
+	case "role":
+		return runRole(cfg.Database, args[1:])
+	case "normalize-emails":
+		return runNormalizeEmails(cfg, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// runMigrate выполняет подкоманду migrate.
+func runMigrate(cfg config.DatabaseConfig, args []string) error {
+	if cfg.Driver != config.DriverPostgres {
+		return fmt.Errorf("migrations require database.driver %q", config.DriverPostgres)
+	}
+
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := openPostgres(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := migrator.New(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		slog.Info("migrations applied", slog.Int("count", applied))
+
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			if n, err = strconv.Atoi(args[1]); err != nil || n <= 0 {
+				return fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+		}
+
+		reverted, err := m.Down(ctx, n)
+		if err != nil {
+			return err
+		}
+		slog.Info("migrations reverted", slog.Int("count", reverted))
+
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		slog.Info("schema version", slog.Uint64("version", status.Version), slog.Bool("dirty", status.Dirty))
+		for _, migration := range status.Migrations {
+			state := "applied"
+			if migration.Version > status.Version {
+				state = "pending"
+			}
+			slog.Info("migration",
+				slog.Uint64("version", migration.Version),
+				slog.String("name", migration.Name),
+				slog.String("state", state),
+			)
+		}
+
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+
+		if err := m.Force(ctx, version); err != nil {
+			return err
+		}
+		slog.Info("schema version forced", slog.Uint64("version", version))
+
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
+
+// autoMigrate применяет миграции при старте сервиса.
+func autoMigrate(store *storage) error {
+	if store.db == nil {
+		return nil
+	}
+
+	m, err := migrator.New(store.db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	applied, err := m.Up(context.Background())
+	if err != nil {
+		return fmt.Errorf("auto migrate: %w", err)
+	}
+
+	slog.Info("migrations applied on startup", slog.Int("count", applied))
+
+	return nil
+}
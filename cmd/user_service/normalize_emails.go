@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/email"
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/repository"
+	"github.com/obsessed-gopher/micro-service-guide/internal/config"
+)
+
+const (
+	normalizeEmailsUsage = "usage: user_service normalize-emails check | apply"
+
+	// normalizeEmailsBatch - сколько пользователей читается за один запрос.
+	normalizeEmailsBatch = 1000
+)
+
+// runNormalizeEmails выполняет подкоманду normalize-emails: пересчитывает
+// email_normalized тем же нормализатором, что и сервис. Миграция 000008
+// заполнила колонку через lower(trim(email)), без punycode и правил
+// провайдеров, поэтому её нужно запускать после миграции и при каждом
+// включении email.provider_rules.
+//
+// check только выводит отчёт; apply обновляет всех, кроме дубликатов:
+// активные пользователи одного арендатора, чьи адреса сводятся к одному
+// ящику, остаются без изменений и разрешаются вручную.
+func runNormalizeEmails(cfg *config.Config, args []string) error {
+	if cfg.Database.Driver != config.DriverPostgres {
+		return fmt.Errorf("normalize-emails requires database.driver %q", config.DriverPostgres)
+	}
+
+	if len(args) != 1 || (args[0] != "check" && args[0] != "apply") {
+		return errors.New(normalizeEmailsUsage)
+	}
+
+	db, err := openPostgres(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	backfill := repository.NewPostgresEmailBackfill(db)
+	plan := newEmailBackfillPlan(email.NewNormalizer(cfg.Email.ProviderRules))
+
+	for afterID := ""; ; {
+		records, err := backfill.Find(ctx, afterID, normalizeEmailsBatch)
+		if err != nil {
+			return err
+		}
+
+		plan.add(records)
+
+		if len(records) < normalizeEmailsBatch {
+			break
+		}
+
+		afterID = records[len(records)-1].ID
+	}
+
+	duplicates := plan.excludeDuplicates()
+
+	slog.Info("emails checked",
+		slog.Int("to_update", len(plan.changed)),
+		slog.Int("duplicates", duplicates),
+		slog.Int("invalid", plan.invalid),
+	)
+
+	if args[0] == "apply" && len(plan.changed) > 0 {
+		if err := backfill.SetNormalized(ctx, plan.changed); err != nil {
+			return err
+		}
+
+		slog.Info("emails normalized", slog.Int("count", len(plan.changed)))
+	}
+
+	if duplicates > 0 {
+		return fmt.Errorf("%d duplicate emails must be resolved manually", duplicates)
+	}
+
+	return nil
+}
+
+// mailbox - каноничный адрес внутри арендатора, уникальный среди активных пользователей.
+type mailbox struct{ tenantID, email string }
+
+// emailBackfillPlan собирает изменения email_normalized по пачкам записей.
+type emailBackfillPlan struct {
+	normalizer *email.Normalizer
+
+	changed map[string]string    // ID -> новый каноничный email
+	owners  map[mailbox][]string // неудалённые пользователи каждого ящика
+	invalid int
+}
+
+func newEmailBackfillPlan(normalizer *email.Normalizer) *emailBackfillPlan {
+	return &emailBackfillPlan{
+		normalizer: normalizer,
+		changed:    make(map[string]string),
+		owners:     make(map[mailbox][]string),
+	}
+}
+
+// add пересчитывает каноничную форму для пачки записей.
+func (p *emailBackfillPlan) add(records []repository.EmailRecord) {
+	for _, record := range records {
+		normalized, err := p.normalizer.Normalize(record.Email)
+		if err != nil {
+			p.invalid++
+			slog.Warn("invalid email, skipped", slog.String("user_id", record.ID))
+			continue
+		}
+
+		if normalized != record.Normalized {
+			p.changed[record.ID] = normalized
+		}
+
+		// Уникальность действует только среди неудалённых пользователей.
+		if !record.Deleted {
+			key := mailbox{record.TenantID, normalized}
+			p.owners[key] = append(p.owners[key], record.ID)
+		}
+	}
+}
+
+// excludeDuplicates убирает из изменений всех владельцев совпавших ящиков
+// и возвращает число таких ящиков. Вызывается после чтения всех записей:
+// владельцы одного ящика могут попасть в разные пачки.
+func (p *emailBackfillPlan) excludeDuplicates() int {
+	duplicates := 0
+
+	for key, ids := range p.owners {
+		if len(ids) < 2 {
+			continue
+		}
+
+		duplicates++
+		slog.Warn("duplicate email",
+			slog.String("tenant_id", key.tenantID),
+			slog.String("email_normalized", key.email),
+			slog.Any("user_ids", ids),
+		)
+
+		for _, id := range ids {
+			delete(p.changed, id)
+		}
+	}
+
+	return duplicates
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/email"
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/repository"
+)
+
+func TestEmailBackfillPlan(t *testing.T) {
+	tests := []struct {
+		name           string
+		batches        [][]repository.EmailRecord
+		wantChanged    map[string]string
+		wantDuplicates int
+		wantInvalid    int
+	}{
+		{
+			name: "already normalized",
+			batches: [][]repository.EmailRecord{{
+				{ID: "1", TenantID: "t", Email: "bob@x.com", Normalized: "bob@x.com"},
+			}},
+			wantChanged: map[string]string{},
+		},
+		{
+			name: "swap between users",
+			batches: [][]repository.EmailRecord{{
+				{ID: "1", TenantID: "t", Email: "j.doe@gmail.com", Normalized: "jdoe+a@gmail.com"},
+				{ID: "2", TenantID: "t", Email: "jdoe+a@x.com", Normalized: "jdoe@gmail.com"},
+			}},
+			wantChanged: map[string]string{"1": "jdoe@gmail.com", "2": "jdoe+a@x.com"},
+		},
+		{
+			name: "duplicates across batches",
+			batches: [][]repository.EmailRecord{
+				{{ID: "1", TenantID: "t", Email: "j.doe@gmail.com", Normalized: "j.doe@gmail.com"}},
+				{{ID: "2", TenantID: "t", Email: "jdoe+news@gmail.com", Normalized: "jdoe+news@gmail.com"}},
+				{{ID: "3", TenantID: "t", Email: "Ann@X.com", Normalized: "Ann@X.com"}},
+			},
+			wantChanged:    map[string]string{"3": "ann@x.com"},
+			wantDuplicates: 1,
+		},
+		{
+			name: "same mailbox in other tenant",
+			batches: [][]repository.EmailRecord{{
+				{ID: "1", TenantID: "a", Email: "j.doe@gmail.com", Normalized: "j.doe@gmail.com"},
+				{ID: "2", TenantID: "b", Email: "jdoe@gmail.com", Normalized: "jdoe@gmail.com"},
+			}},
+			wantChanged: map[string]string{"1": "jdoe@gmail.com"},
+		},
+		{
+			name: "deleted user is not an owner",
+			batches: [][]repository.EmailRecord{{
+				{ID: "1", TenantID: "t", Email: "j.doe@gmail.com", Normalized: "j.doe@gmail.com"},
+				{ID: "2", TenantID: "t", Email: "jdoe@gmail.com", Normalized: "jdoe@gmail.com", Deleted: true},
+			}},
+			wantChanged: map[string]string{"1": "jdoe@gmail.com"},
+		},
+		{
+			name: "invalid email skipped",
+			batches: [][]repository.EmailRecord{{
+				{ID: "1", TenantID: "t", Email: "+news@gmail.com", Normalized: "+news@gmail.com"},
+			}},
+			wantChanged: map[string]string{},
+			wantInvalid: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := newEmailBackfillPlan(email.NewNormalizer(true))
+			for _, records := range tt.batches {
+				plan.add(records)
+			}
+
+			if got := plan.excludeDuplicates(); got != tt.wantDuplicates {
+				t.Errorf("excludeDuplicates() = %d, want %d", got, tt.wantDuplicates)
+			}
+
+			if plan.invalid != tt.wantInvalid {
+				t.Errorf("invalid = %d, want %d", plan.invalid, tt.wantInvalid)
+			}
+
+			if !maps.Equal(plan.changed, tt.wantChanged) {
+				t.Errorf("changed = %v, want %v", plan.changed, tt.wantChanged)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/repository"
+	"github.com/obsessed-gopher/micro-service-guide/internal/config"
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+const roleUsage = "usage: user_service role grant | revoke USER_ID ROLE"
+
+// runRole выполняет подкоманду role. Нужна, чтобы выдать роль первому
+// администратору: через API роли выдаёт только администратор.
+func runRole(cfg config.DatabaseConfig, args []string) error {
+	if cfg.Driver != config.DriverPostgres {
+		return fmt.Errorf("roles require database.driver %q", config.DriverPostgres)
+	}
+
+	if len(args) != 3 {
+		return errors.New(roleUsage)
+	}
+
+	userID, role := args[1], types.Role(args[2])
+	if !role.IsValid() {
+		return fmt.Errorf("%w %q", types.ErrInvalidRole, role)
+	}
+
+	db, err := openPostgres(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// ID пользователя уникален во всех арендаторах
+	ctx := tenant.WithAll(context.Background())
+
+	users, err := repository.NewPostgresRepository(db).Find(ctx, models.UserFilter{IDs: []string{userID}}, &models.Pagination{Limit: 1})
+	if err != nil {
+		return err
+	}
+
+	if len(users) == 0 {
+		return types.ErrUserNotFound
+	}
+
+	roles := repository.NewPostgresRoleRepository(db)
+
+	switch args[0] {
+	case "grant":
+		err = roles.Add(ctx, userID, role)
+	case "revoke":
+		err = roles.Remove(ctx, userID, role)
+	default:
+		return errors.New(roleUsage)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	slog.Info("role updated", slog.String("action", args[0]), slog.String("user_id", userID), slog.String("role", string(role)))
+
+	return nil
+}
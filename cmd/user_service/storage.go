@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	// Регистрирует драйвер "postgres" для database/sql.
+	_ "github.com/lib/pq"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/memory"
+	"github.com/obsessed-gopher/micro-service-guide/internal/adapters/repository"
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/health"
+	"github.com/obsessed-gopher/micro-service-guide/internal/config"
+	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
+)
+
+// storage - репозитории выбранного хранилища и подключение к БД, если оно есть.
+type storage struct {
+	db            *sql.DB
+	users         usecases.UserRepository
+	sessions      usecases.SessionRepository
+	resets        usecases.PasswordResetRepository
+	verifications usecases.EmailVerificationRepository
+	challenges    usecases.MFAChallengeRepository
+	attempts      usecases.LoginAttemptRepository
+	roles         usecases.RoleRepository
+	orgs          usecases.OrganizationRepository
+	memberships   usecases.MembershipRepository
+	pinger        health.Pinger
+}
+
+// newStorage создаёт репозитории в зависимости от database.driver.
+func newStorage(cfg config.DatabaseConfig) (*storage, error) {
+	switch cfg.Driver {
+	case config.DriverMemory, "":
+		memberships := memory.NewMemoryMembershipRepository()
+		users := memory.NewMemoryUserRepository()
+		users.CascadeMemberships(memberships)
+
+		return &storage{
+			users:         users,
+			sessions:      memory.NewMemorySessionRepository(),
+			resets:        memory.NewMemoryPasswordResetRepository(),
+			verifications: memory.NewMemoryEmailVerificationRepository(),
+			challenges:    memory.NewMemoryMFAChallengeRepository(),
+			attempts:      memory.NewMemoryLoginAttemptRepository(),
+			roles:         memory.NewMemoryRoleRepository(),
+			orgs:          memory.NewMemoryOrganizationRepository(),
+			memberships:   memberships,
+			pinger:        users,
+		}, nil
+	case config.DriverPostgres:
+		db, err := openPostgres(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		users := repository.NewPostgresRepository(db)
+
+		return &storage{
+			db:            db,
+			users:         users,
+			sessions:      repository.NewPostgresSessionRepository(db),
+			resets:        repository.NewPostgresPasswordResetRepository(db),
+			verifications: repository.NewPostgresEmailVerificationRepository(db),
+			challenges:    repository.NewPostgresMFAChallengeRepository(db),
+			attempts:      repository.NewPostgresLoginAttemptRepository(db),
+			roles:         repository.NewPostgresRoleRepository(db),
+			orgs:          repository.NewPostgresOrganizationRepository(db),
+			memberships:   repository.NewPostgresMembershipRepository(db),
+			pinger:        users,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", cfg.Driver)
+	}
+}
+
+// Close закрывает подключение к БД.
+func (s *storage) Close() error {
+	if s.db == nil {
+		return nil
+	}
+
+	return s.db.Close()
+}
+
+// openPostgres открывает пул подключений и проверяет доступность БД.
+func openPostgres(cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open(config.DriverPostgres, cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	ctx := context.Background()
+	if cfg.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.ConnectTimeout)
+		defer cancel()
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+
+	return db, nil
+}
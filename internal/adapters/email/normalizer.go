@@ -0,0 +1,79 @@
+// Package email содержит канонизацию email адресов.
+package email
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// providerRule - правила почтового провайдера, при которых разные
+// адреса доставляются в один ящик.
+type providerRule struct {
+	domain    string // каноничный домен провайдера
+	stripDots bool   // точки в локальной части игнорируются
+	stripTag  bool   // всё после "+" в локальной части игнорируется
+}
+
+// providerRules - правила известных провайдеров по домену.
+var providerRules = map[string]providerRule{
+	"gmail.com":      {domain: "gmail.com", stripDots: true, stripTag: true},
+	"googlemail.com": {domain: "gmail.com", stripDots: true, stripTag: true},
+}
+
+// Normalizer приводит email к каноничной форме для проверки уникальности:
+// trim, нижний регистр, домен в punycode и, опционально, правила провайдеров
+// (например, "J.Doe+news@GoogleMail.com" -> "jdoe@gmail.com").
+type Normalizer struct {
+	providerRules bool
+}
+
+// NewNormalizer создаёт новый нормализатор email.
+func NewNormalizer(providerRules bool) *Normalizer {
+	return &Normalizer{providerRules: providerRules}
+}
+
+// Normalize возвращает каноничную форму email или ErrInvalidEmail.
+func (n *Normalizer) Normalize(address string) (string, error) {
+	address = strings.TrimSpace(address)
+
+	at := strings.LastIndexByte(address, '@')
+	if at <= 0 || at == len(address)-1 {
+		return "", types.ErrInvalidEmail
+	}
+
+	local := strings.ToLower(address[:at])
+
+	domain, err := idna.Lookup.ToASCII(strings.TrimSuffix(address[at+1:], "."))
+	if err != nil {
+		return "", types.ErrInvalidEmail
+	}
+
+	domain = strings.ToLower(domain)
+
+	if rule, ok := providerRules[domain]; ok && n.providerRules {
+		local, domain = rule.apply(local)
+	}
+
+	if local == "" {
+		return "", types.ErrInvalidEmail
+	}
+
+	return local + "@" + domain, nil
+}
+
+func (r providerRule) apply(local string) (string, string) {
+	if r.stripTag {
+		if plus := strings.IndexByte(local, '+'); plus >= 0 {
+			local = local[:plus]
+		}
+	}
+
+	if r.stripDots {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+
+	return local, r.domain
+}
@@ -0,0 +1,39 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+func TestNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		name          string
+		providerRules bool
+		input         string
+		want          string
+		wantErr       error
+	}{
+		{name: "case and spaces", input: "  Bob@X.com ", want: "bob@x.com"},
+		{name: "idn domain", input: "user@Пример.РФ", want: "user@xn--e1afmkfd.xn--p1ai"},
+		{name: "gmail without rules", input: "J.Doe+news@gmail.com", want: "j.doe+news@gmail.com"},
+		{name: "gmail with rules", providerRules: true, input: "J.Doe+news@GoogleMail.com", want: "jdoe@gmail.com"},
+		{name: "other provider keeps dots", providerRules: true, input: "j.doe+news@example.com", want: "j.doe+news@example.com"},
+		{name: "missing domain", input: "bob@", wantErr: types.ErrInvalidEmail},
+		{name: "missing local part", input: "@x.com", wantErr: types.ErrInvalidEmail},
+		{name: "only tag", providerRules: true, input: "+news@gmail.com", wantErr: types.ErrInvalidEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewNormalizer(tt.providerRules).Normalize(tt.input)
+			if err != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@
 package hasher
 
 import (
+	"strings"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -30,4 +32,15 @@ func (h *BcryptHasher) Hash(password string) (string, error) {
 // Compare сравнивает хэш с паролем.
 func (h *BcryptHasher) Compare(hash, password string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// Identify проверяет, что хэш создан bcrypt.
+func (h *BcryptHasher) Identify(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// NeedsRehash проверяет, что хэш создан с другой стоимостью.
+func (h *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
 }
\ No newline at end of file
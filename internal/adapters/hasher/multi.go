@@ -0,0 +1,52 @@
+package hasher
+
+// Algorithm - алгоритм хэширования, распознающий свои хэши по префиксу.
+type Algorithm interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) bool
+	Identify(hash string) bool
+	NeedsRehash(hash string) bool
+}
+
+// MultiHasher хэширует пароли текущим алгоритмом и проверяет хэши любого
+// из известных алгоритмов, чтобы их можно было постепенно перевести
+// на текущий при входе пользователей.
+type MultiHasher struct {
+	current    Algorithm
+	algorithms []Algorithm
+}
+
+// NewMultiHasher создаёт hasher с текущим алгоритмом и устаревшими,
+// хэши которых ещё нужно проверять.
+func NewMultiHasher(current Algorithm, legacy ...Algorithm) *MultiHasher {
+	return &MultiHasher{
+		current:    current,
+		algorithms: append([]Algorithm{current}, legacy...),
+	}
+}
+
+// Hash хэширует пароль текущим алгоритмом.
+func (h *MultiHasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+// Compare сравнивает пароль с хэшем алгоритмом, которым хэш создан.
+func (h *MultiHasher) Compare(hash, password string) bool {
+	for _, a := range h.algorithms {
+		if a.Identify(hash) {
+			return a.Compare(hash, password)
+		}
+	}
+
+	return false
+}
+
+// NeedsRehash проверяет, что хэш создан другим алгоритмом или
+// с устаревшими параметрами текущего.
+func (h *MultiHasher) NeedsRehash(hash string) bool {
+	if !h.current.Identify(hash) {
+		return true
+	}
+
+	return h.current.NeedsRehash(hash)
+}
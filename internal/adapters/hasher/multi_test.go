@@ -0,0 +1,91 @@
+package hasher
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2Params - минимальные параметры, чтобы тесты не тратили память.
+var testArgon2Params = Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1}
+
+func TestArgon2Hasher(t *testing.T) {
+	h := NewArgon2Hasher(testArgon2Params)
+
+	hash, err := h.Hash("password123")
+	if err != nil {
+		t.Fatalf("Hash() unexpected error = %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("Hash() = %q, want PHC format", hash)
+	}
+
+	if !h.Compare(hash, "password123") {
+		t.Error("Compare() = false for correct password")
+	}
+
+	if h.Compare(hash, "password124") {
+		t.Error("Compare() = true for wrong password")
+	}
+
+	if h.Compare("$argon2id$v=19$garbage", "password123") {
+		t.Error("Compare() = true for malformed hash")
+	}
+
+	if h.NeedsRehash(hash) {
+		t.Error("NeedsRehash() = true for current params")
+	}
+
+	stronger := NewArgon2Hasher(Argon2Params{Memory: 128, Iterations: 1, Parallelism: 1})
+	if !stronger.NeedsRehash(hash) {
+		t.Error("NeedsRehash() = false for outdated params")
+	}
+
+	if !stronger.Compare(hash, "password123") {
+		t.Error("Compare() must use params stored in hash")
+	}
+}
+
+func TestMultiHasher(t *testing.T) {
+	bcryptHasher := NewBcryptHasher(bcrypt.MinCost)
+	argon2Hasher := NewArgon2Hasher(testArgon2Params)
+
+	legacy, err := bcryptHasher.Hash("password123")
+	if err != nil {
+		t.Fatalf("Hash() unexpected error = %v", err)
+	}
+
+	h := NewMultiHasher(argon2Hasher, bcryptHasher)
+
+	if !h.Compare(legacy, "password123") {
+		t.Error("Compare() = false for legacy bcrypt hash")
+	}
+
+	if h.Compare(legacy, "wrong") {
+		t.Error("Compare() = true for wrong password")
+	}
+
+	if !h.NeedsRehash(legacy) {
+		t.Error("NeedsRehash() = false for bcrypt hash with argon2id current")
+	}
+
+	hash, err := h.Hash("password123")
+	if err != nil {
+		t.Fatalf("Hash() unexpected error = %v", err)
+	}
+
+	if !argon2Hasher.Identify(hash) || h.NeedsRehash(hash) {
+		t.Errorf("Hash() = %q, want current argon2id hash", hash)
+	}
+
+	if h.Compare("plain", "plain") {
+		t.Error("Compare() = true for unknown hash format")
+	}
+
+	// Повышение стоимости bcrypt тоже требует перехэширования.
+	if !NewMultiHasher(NewBcryptHasher(bcrypt.MinCost + 1)).NeedsRehash(legacy) {
+		t.Error("NeedsRehash() = false for outdated bcrypt cost")
+	}
+}
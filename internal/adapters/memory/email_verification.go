@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// MemoryEmailVerificationRepository - in-memory реализация репозитория токенов подтверждения email.
+type MemoryEmailVerificationRepository struct {
+	mu            sync.RWMutex
+	verifications map[string]models.EmailVerification
+}
+
+// NewMemoryEmailVerificationRepository создаёт новый in-memory репозиторий токенов подтверждения email.
+func NewMemoryEmailVerificationRepository() *MemoryEmailVerificationRepository {
+	return &MemoryEmailVerificationRepository{
+		verifications: make(map[string]models.EmailVerification),
+	}
+}
+
+// Create сохраняет токен подтверждения email.
+func (r *MemoryEmailVerificationRepository) Create(ctx context.Context, verification *models.EmailVerification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.verifications[verification.ID] = *verification
+
+	return nil
+}
+
+// Find возвращает токены подтверждения email по фильтру, новые первыми.
+func (r *MemoryEmailVerificationRepository) Find(ctx context.Context, filter models.EmailVerificationFilter) ([]*models.EmailVerification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []*models.EmailVerification
+
+	for _, verification := range r.verifications {
+		if !r.matchesFilter(&verification, filter) {
+			continue
+		}
+
+		found := verification
+		filtered = append(filtered, &found)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
+	})
+
+	return filtered, nil
+}
+
+// Count возвращает количество токенов подтверждения email по фильтру.
+func (r *MemoryEmailVerificationRepository) Count(ctx context.Context, filter models.EmailVerificationFilter) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+
+	for _, verification := range r.verifications {
+		if r.matchesFilter(&verification, filter) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// Use помечает неиспользованные токены по фильтру использованными.
+// Возвращает количество помеченных.
+func (r *MemoryEmailVerificationRepository) Use(ctx context.Context, filter models.EmailVerificationFilter, usedAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+
+	for id, verification := range r.verifications {
+		if verification.UsedAt != nil || !r.matchesFilter(&verification, filter) {
+			continue
+		}
+
+		verification.UsedAt = &usedAt
+		r.verifications[id] = verification
+		count++
+	}
+
+	return count, nil
+}
+
+// matchesFilter проверяет, соответствует ли токен фильтру.
+func (r *MemoryEmailVerificationRepository) matchesFilter(verification *models.EmailVerification, filter models.EmailVerificationFilter) bool {
+	if len(filter.IDs) > 0 && !containsString(filter.IDs, verification.ID) {
+		return false
+	}
+
+	if len(filter.UserIDs) > 0 && !containsString(filter.UserIDs, verification.UserID) {
+		return false
+	}
+
+	if len(filter.TokenHashes) > 0 && !containsString(filter.TokenHashes, verification.TokenHash) {
+		return false
+	}
+
+	if filter.CreatedAfter != nil && !verification.CreatedAt.After(*filter.CreatedAfter) {
+		return false
+	}
+
+	if filter.ActiveAt != nil && !verification.IsActive(*filter.ActiveAt) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// MemoryLoginAttemptRepository - in-memory реализация хранилища счётчиков неудачных попыток входа.
+type MemoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	failures map[string]models.LoginFailures
+}
+
+// NewMemoryLoginAttemptRepository создаёт новое in-memory хранилище счётчиков неудачных попыток входа.
+func NewMemoryLoginAttemptRepository() *MemoryLoginAttemptRepository {
+	return &MemoryLoginAttemptRepository{
+		failures: make(map[string]models.LoginFailures),
+	}
+}
+
+// Find возвращает счётчики по ключам. Ключи без ошибок пропускаются.
+func (r *MemoryLoginAttemptRepository) Find(ctx context.Context, keys []string) ([]*models.LoginFailures, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var found []*models.LoginFailures
+
+	for _, key := range keys {
+		if f, ok := r.failures[key]; ok {
+			found = append(found, &f)
+		}
+	}
+
+	return found, nil
+}
+
+// AddFailure увеличивает счётчик ключа и возвращает его новое состояние.
+// Если последняя ошибка была раньше resetBefore, счёт начинается заново.
+func (r *MemoryLoginAttemptRepository) AddFailure(ctx context.Context, key string, at, resetBefore time.Time) (*models.LoginFailures, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, ok := r.failures[key]
+	if !ok || f.LastFailureAt.Before(resetBefore) {
+		f = models.LoginFailures{Key: key}
+	}
+
+	f.Count++
+	f.LastFailureAt = at
+	r.failures[key] = f
+
+	return &f, nil
+}
+
+// RemoveFailure уменьшает счётчики ключей на одну попытку.
+func (r *MemoryLoginAttemptRepository) RemoveFailure(ctx context.Context, keys []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		if f, ok := r.failures[key]; ok && f.Count > 0 {
+			f.Count--
+			r.failures[key] = f
+		}
+	}
+
+	return nil
+}
+
+// Reset удаляет счётчики по ключам.
+func (r *MemoryLoginAttemptRepository) Reset(ctx context.Context, keys []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		delete(r.failures, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// membershipKey - ключ членства: пользователь состоит в организации не больше одного раза.
+type membershipKey struct {
+	orgID  string
+	userID string
+}
+
+// MemoryMembershipRepository - in-memory реализация хранилища членства в организациях.
+type MemoryMembershipRepository struct {
+	mu          sync.RWMutex
+	memberships map[membershipKey]models.Membership
+}
+
+// NewMemoryMembershipRepository создаёт новое in-memory хранилище членства в организациях.
+func NewMemoryMembershipRepository() *MemoryMembershipRepository {
+	return &MemoryMembershipRepository{
+		memberships: make(map[membershipKey]models.Membership),
+	}
+}
+
+// Add добавляет участника. Если пользователь уже состоит
+// в организации, возвращает types.ErrMemberAlreadyExists.
+func (r *MemoryMembershipRepository) Add(ctx context.Context, membership *models.Membership) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := membershipKey{orgID: membership.OrganizationID, userID: membership.UserID}
+	if _, ok := r.memberships[key]; ok {
+		return types.ErrMemberAlreadyExists
+	}
+
+	r.memberships[key] = *membership
+
+	return nil
+}
+
+// Find возвращает членство по фильтру в порядке добавления.
+func (r *MemoryMembershipRepository) Find(ctx context.Context, filter models.MembershipFilter) ([]*models.Membership, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []*models.Membership
+
+	for _, membership := range r.memberships {
+		if !r.matchesFilter(&membership, filter) {
+			continue
+		}
+
+		found := membership
+		filtered = append(filtered, &found)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		if !filtered[i].CreatedAt.Equal(filtered[j].CreatedAt) {
+			return filtered[i].CreatedAt.Before(filtered[j].CreatedAt)
+		}
+		return filtered[i].UserID < filtered[j].UserID
+	})
+
+	return filtered, nil
+}
+
+// Remove удаляет членство по фильтру. Возвращает количество удалённых.
+func (r *MemoryMembershipRepository) Remove(ctx context.Context, filter models.MembershipFilter) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+
+	for key, membership := range r.memberships {
+		if r.matchesFilter(&membership, filter) {
+			delete(r.memberships, key)
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// matchesFilter проверяет, соответствует ли членство фильтру.
+func (r *MemoryMembershipRepository) matchesFilter(membership *models.Membership, filter models.MembershipFilter) bool {
+	if len(filter.OrganizationIDs) > 0 && !containsString(filter.OrganizationIDs, membership.OrganizationID) {
+		return false
+	}
+
+	if len(filter.UserIDs) > 0 && !containsString(filter.UserIDs, membership.UserID) {
+		return false
+	}
+
+	return true
+}
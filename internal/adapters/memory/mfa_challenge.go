@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// MemoryMFAChallengeRepository - in-memory реализация репозитория токенов второго шага входа.
+type MemoryMFAChallengeRepository struct {
+	mu         sync.RWMutex
+	challenges map[string]models.MFAChallenge
+}
+
+// NewMemoryMFAChallengeRepository создаёт новый in-memory репозиторий токенов второго шага входа.
+func NewMemoryMFAChallengeRepository() *MemoryMFAChallengeRepository {
+	return &MemoryMFAChallengeRepository{
+		challenges: make(map[string]models.MFAChallenge),
+	}
+}
+
+// Create сохраняет токен второго шага входа.
+func (r *MemoryMFAChallengeRepository) Create(ctx context.Context, challenge *models.MFAChallenge) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.challenges[challenge.ID] = *challenge
+
+	return nil
+}
+
+// Find возвращает токены второго шага входа по фильтру, новые первыми.
+func (r *MemoryMFAChallengeRepository) Find(ctx context.Context, filter models.MFAChallengeFilter) ([]*models.MFAChallenge, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []*models.MFAChallenge
+
+	for _, challenge := range r.challenges {
+		if !r.matchesFilter(&challenge, filter) {
+			continue
+		}
+
+		found := challenge
+		filtered = append(filtered, &found)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
+	})
+
+	return filtered, nil
+}
+
+// AddAttempt увеличивает счётчик неверных кодов и возвращает новое значение.
+func (r *MemoryMFAChallengeRepository) AddAttempt(ctx context.Context, id string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	challenge, ok := r.challenges[id]
+	if !ok {
+		return 0, nil
+	}
+
+	challenge.Attempts++
+	r.challenges[id] = challenge
+
+	return challenge.Attempts, nil
+}
+
+// Use помечает неиспользованные токены по фильтру использованными.
+// Возвращает количество помеченных.
+func (r *MemoryMFAChallengeRepository) Use(ctx context.Context, filter models.MFAChallengeFilter, usedAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+
+	for id, challenge := range r.challenges {
+		if challenge.UsedAt != nil || !r.matchesFilter(&challenge, filter) {
+			continue
+		}
+
+		challenge.UsedAt = &usedAt
+		r.challenges[id] = challenge
+		count++
+	}
+
+	return count, nil
+}
+
+// matchesFilter проверяет, соответствует ли токен фильтру.
+func (r *MemoryMFAChallengeRepository) matchesFilter(challenge *models.MFAChallenge, filter models.MFAChallengeFilter) bool {
+	if len(filter.IDs) > 0 && !containsString(filter.IDs, challenge.ID) {
+		return false
+	}
+
+	if len(filter.UserIDs) > 0 && !containsString(filter.UserIDs, challenge.UserID) {
+		return false
+	}
+
+	if len(filter.TokenHashes) > 0 && !containsString(filter.TokenHashes, challenge.TokenHash) {
+		return false
+	}
+
+	if filter.ActiveAt != nil && !challenge.IsActive(*filter.ActiveAt) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// MemoryOrganizationRepository - in-memory реализация репозитория организаций.
+// Все методы ограничены арендатором из контекста.
+type MemoryOrganizationRepository struct {
+	mu   sync.RWMutex
+	orgs map[string]models.Organization
+}
+
+// NewMemoryOrganizationRepository создаёт новый in-memory репозиторий организаций.
+func NewMemoryOrganizationRepository() *MemoryOrganizationRepository {
+	return &MemoryOrganizationRepository{
+		orgs: make(map[string]models.Organization),
+	}
+}
+
+// Create сохраняет организацию в арендаторе из контекста.
+func (r *MemoryOrganizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	tenantID, all, err := tenant.Scope(ctx)
+	if err != nil || all {
+		return types.ErrTenantRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	org.TenantID = tenantID
+	r.orgs[org.ID] = *org
+
+	return nil
+}
+
+// Find возвращает организации по фильтру, упорядоченные по названию.
+func (r *MemoryOrganizationRepository) Find(
+	ctx context.Context,
+	filter models.OrganizationFilter,
+	pagination *models.Pagination,
+) ([]*models.Organization, error) {
+	filtered, err := r.filter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].Name != filtered[j].Name {
+			return filtered[i].Name < filtered[j].Name
+		}
+		return filtered[i].ID < filtered[j].ID
+	})
+
+	if pagination != nil {
+		offset := min(pagination.Offset, len(filtered))
+		filtered = filtered[offset:]
+
+		if pagination.Limit > 0 && pagination.Limit < len(filtered) {
+			filtered = filtered[:pagination.Limit]
+		}
+	}
+
+	return filtered, nil
+}
+
+// Count возвращает количество организаций по фильтру.
+func (r *MemoryOrganizationRepository) Count(ctx context.Context, filter models.OrganizationFilter) (int, error) {
+	filtered, err := r.filter(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(filtered), nil
+}
+
+// Delete удаляет организации по фильтру. Возвращает количество удалённых.
+func (r *MemoryOrganizationRepository) Delete(ctx context.Context, filter models.OrganizationFilter) (int, error) {
+	inTenant, err := tenantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+
+	for id, org := range r.orgs {
+		if inTenant(org.TenantID) && r.matchesFilter(&org, filter) {
+			delete(r.orgs, id)
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// filter возвращает копии организаций арендатора, подходящих под фильтр.
+func (r *MemoryOrganizationRepository) filter(ctx context.Context, filter models.OrganizationFilter) ([]*models.Organization, error) {
+	inTenant, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []*models.Organization
+
+	for _, org := range r.orgs {
+		if !inTenant(org.TenantID) || !r.matchesFilter(&org, filter) {
+			continue
+		}
+
+		found := org
+		filtered = append(filtered, &found)
+	}
+
+	return filtered, nil
+}
+
+// matchesFilter проверяет, соответствует ли организация фильтру.
+func (r *MemoryOrganizationRepository) matchesFilter(org *models.Organization, filter models.OrganizationFilter) bool {
+	return len(filter.IDs) == 0 || containsString(filter.IDs, org.ID)
+}
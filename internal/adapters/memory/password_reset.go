@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// MemoryPasswordResetRepository - in-memory реализация репозитория токенов сброса пароля.
+type MemoryPasswordResetRepository struct {
+	mu     sync.RWMutex
+	resets map[string]models.PasswordReset
+}
+
+// NewMemoryPasswordResetRepository создаёт новый in-memory репозиторий токенов сброса пароля.
+func NewMemoryPasswordResetRepository() *MemoryPasswordResetRepository {
+	return &MemoryPasswordResetRepository{
+		resets: make(map[string]models.PasswordReset),
+	}
+}
+
+// Create сохраняет токен сброса пароля.
+func (r *MemoryPasswordResetRepository) Create(ctx context.Context, reset *models.PasswordReset) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resets[reset.ID] = *reset
+
+	return nil
+}
+
+// Find возвращает токены сброса пароля по фильтру, новые первыми.
+func (r *MemoryPasswordResetRepository) Find(ctx context.Context, filter models.PasswordResetFilter) ([]*models.PasswordReset, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []*models.PasswordReset
+
+	for _, reset := range r.resets {
+		if !r.matchesFilter(&reset, filter) {
+			continue
+		}
+
+		found := reset
+		filtered = append(filtered, &found)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
+	})
+
+	return filtered, nil
+}
+
+// Count возвращает количество токенов сброса пароля по фильтру.
+func (r *MemoryPasswordResetRepository) Count(ctx context.Context, filter models.PasswordResetFilter) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+
+	for _, reset := range r.resets {
+		if r.matchesFilter(&reset, filter) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// Use помечает неиспользованные токены по фильтру использованными.
+// Возвращает количество помеченных.
+func (r *MemoryPasswordResetRepository) Use(ctx context.Context, filter models.PasswordResetFilter, usedAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+
+	for id, reset := range r.resets {
+		if reset.UsedAt != nil || !r.matchesFilter(&reset, filter) {
+			continue
+		}
+
+		reset.UsedAt = &usedAt
+		r.resets[id] = reset
+		count++
+	}
+
+	return count, nil
+}
+
+// matchesFilter проверяет, соответствует ли токен фильтру.
+func (r *MemoryPasswordResetRepository) matchesFilter(reset *models.PasswordReset, filter models.PasswordResetFilter) bool {
+	if len(filter.IDs) > 0 && !containsString(filter.IDs, reset.ID) {
+		return false
+	}
+
+	if len(filter.UserIDs) > 0 && !containsString(filter.UserIDs, reset.UserID) {
+		return false
+	}
+
+	if len(filter.TokenHashes) > 0 && !containsString(filter.TokenHashes, reset.TokenHash) {
+		return false
+	}
+
+	if filter.CreatedAfter != nil && !reset.CreatedAt.After(*filter.CreatedAfter) {
+		return false
+	}
+
+	if filter.ActiveAt != nil && !reset.IsActive(*filter.ActiveAt) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// MemoryRoleRepository - in-memory реализация хранилища ролей пользователей.
+type MemoryRoleRepository struct {
+	mu    sync.RWMutex
+	roles map[string][]types.Role
+}
+
+// NewMemoryRoleRepository создаёт новое in-memory хранилище ролей пользователей.
+func NewMemoryRoleRepository() *MemoryRoleRepository {
+	return &MemoryRoleRepository{
+		roles: make(map[string][]types.Role),
+	}
+}
+
+// List возвращает роли пользователя в алфавитном порядке.
+func (r *MemoryRoleRepository) List(ctx context.Context, userID string) ([]types.Role, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.roles[userID]), nil
+}
+
+// Add выдаёт роль. Повторная выдача той же роли не считается ошибкой.
+func (r *MemoryRoleRepository) Add(ctx context.Context, userID string, role types.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roles := r.roles[userID]
+	if slices.Contains(roles, role) {
+		return nil
+	}
+
+	roles = append(slices.Clone(roles), role)
+	slices.Sort(roles)
+	r.roles[userID] = roles
+
+	return nil
+}
+
+// Remove отзывает роль пользователя.
+func (r *MemoryRoleRepository) Remove(ctx context.Context, userID string, role types.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roles := slices.DeleteFunc(slices.Clone(r.roles[userID]), func(existing types.Role) bool {
+		return existing == role
+	})
+
+	if len(roles) == 0 {
+		delete(r.roles, userID)
+		return nil
+	}
+
+	r.roles[userID] = roles
+
+	return nil
+}
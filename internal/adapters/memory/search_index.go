@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"strings"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// Веса полей при ранжировании: совпадение в имени важнее, чем в email.
+const (
+	nameWeight  = 2
+	emailWeight = 1
+)
+
+// searchIndex - инвертированный индекс пользователей: терм -> ID -> вес.
+// Не потокобезопасен, защищается мьютексом репозитория.
+type searchIndex struct {
+	postings map[string]map[string]int
+	terms    map[string][]string
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[string]int),
+		terms:    make(map[string][]string),
+	}
+}
+
+// add индексирует пользователя, заменяя прежние термы.
+func (idx *searchIndex) add(user *models.User) {
+	idx.remove(user.ID)
+
+	weights := make(map[string]int)
+
+	for _, term := range models.SearchTerms(user.Email) {
+		weights[term] = max(weights[term], emailWeight)
+	}
+
+	for _, term := range models.SearchTerms(user.Name) {
+		weights[term] = max(weights[term], nameWeight)
+	}
+
+	terms := make([]string, 0, len(weights))
+
+	for term, weight := range weights {
+		if idx.postings[term] == nil {
+			idx.postings[term] = make(map[string]int)
+		}
+
+		idx.postings[term][user.ID] = weight
+		terms = append(terms, term)
+	}
+
+	idx.terms[user.ID] = terms
+}
+
+// remove удаляет пользователя из индекса.
+func (idx *searchIndex) remove(id string) {
+	for _, term := range idx.terms[id] {
+		delete(idx.postings[term], id)
+
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+
+	delete(idx.terms, id)
+}
+
+// search возвращает релевантность пользователей, у которых каждый терм запроса
+// совпадает с началом какого-либо их терма (как prefix-запрос tsquery).
+func (idx *searchIndex) search(queryTerms []string) map[string]int {
+	var scores map[string]int
+
+	for _, queryTerm := range queryTerms {
+		matched := make(map[string]int)
+
+		for term, ids := range idx.postings {
+			if !strings.HasPrefix(term, queryTerm) {
+				continue
+			}
+
+			for id, weight := range ids {
+				matched[id] = max(matched[id], weight)
+			}
+		}
+
+		if scores == nil {
+			scores = matched
+			continue
+		}
+
+		for id, score := range scores {
+			weight, ok := matched[id]
+			if !ok {
+				delete(scores, id)
+				continue
+			}
+
+			scores[id] = score + weight
+		}
+	}
+
+	return scores
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// MemorySessionRepository - in-memory реализация репозитория сессий.
+type MemorySessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]models.Session
+}
+
+// NewMemorySessionRepository создаёт новый in-memory репозиторий сессий.
+func NewMemorySessionRepository() *MemorySessionRepository {
+	return &MemorySessionRepository{
+		sessions: make(map[string]models.Session),
+	}
+}
+
+// Create сохраняет сессию.
+func (r *MemorySessionRepository) Create(ctx context.Context, session *models.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session.ID] = *session
+
+	return nil
+}
+
+// Find возвращает сессии по фильтру, новые первыми.
+func (r *MemorySessionRepository) Find(ctx context.Context, filter models.SessionFilter) ([]*models.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []*models.Session
+
+	for _, session := range r.sessions {
+		if !r.matchesFilter(&session, filter) {
+			continue
+		}
+
+		found := session
+		filtered = append(filtered, &found)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
+	})
+
+	return filtered, nil
+}
+
+// Rotate сохраняет сессию с новым refresh токеном, если её токен
+// всё ещё previousHash и она не отозвана.
+func (r *MemorySessionRepository) Rotate(ctx context.Context, session *models.Session, previousHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.sessions[session.ID]
+	if !ok || stored.RevokedAt != nil || stored.RefreshTokenHash != previousHash {
+		return types.ErrInvalidToken
+	}
+
+	r.sessions[session.ID] = *session
+
+	return nil
+}
+
+// Revoke отзывает сессии по фильтру. Возвращает количество отозванных.
+func (r *MemorySessionRepository) Revoke(ctx context.Context, filter models.SessionFilter, revokedAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+
+	for id, session := range r.sessions {
+		if session.RevokedAt != nil || !r.matchesFilter(&session, filter) {
+			continue
+		}
+
+		session.RevokedAt = &revokedAt
+		r.sessions[id] = session
+		count++
+	}
+
+	return count, nil
+}
+
+// matchesFilter проверяет, соответствует ли сессия фильтру.
+func (r *MemorySessionRepository) matchesFilter(session *models.Session, filter models.SessionFilter) bool {
+	if len(filter.IDs) > 0 && !containsString(filter.IDs, session.ID) {
+		return false
+	}
+
+	if len(filter.UserIDs) > 0 && !containsString(filter.UserIDs, session.UserID) {
+		return false
+	}
+
+	if len(filter.RefreshTokenHashes) > 0 && !containsString(filter.RefreshTokenHashes, session.RefreshTokenHash) {
+		return false
+	}
+
+	if filter.ActiveAt != nil && !session.IsActive(*filter.ActiveAt) {
+		return false
+	}
+
+	return true
+}
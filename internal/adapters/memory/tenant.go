@@ -0,0 +1,20 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+)
+
+// tenantScope возвращает проверку принадлежности записи арендатору
+// из контекста. Для системных операций подходит любой арендатор.
+func tenantScope(ctx context.Context) (func(tenantID string) bool, error) {
+	scopeID, all, err := tenant.Scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(tenantID string) bool {
+		return all || tenantID == scopeID
+	}, nil
+}
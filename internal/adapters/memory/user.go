@@ -3,69 +3,196 @@ package memory
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
 	"github.com/obsessed-gopher/micro-service-guide/internal/types"
 )
 
 // MemoryUserRepository - in-memory реализация репозитория (для тестов и демо).
+// Хранит копии пользователей, чтобы изменения модели вне репозитория
+// не попадали в хранилище в обход Update. Все методы ограничены
+// арендатором из контекста (см. пакет tenant).
 type MemoryUserRepository struct {
 	mu    sync.RWMutex
 	users map[string]*models.User
+	index *searchIndex
+
+	memberships *MemoryMembershipRepository
 }
 
 // NewMemoryUserRepository создаёт новый in-memory репозиторий.
 func NewMemoryUserRepository() *MemoryUserRepository {
 	return &MemoryUserRepository{
 		users: make(map[string]*models.User),
+		index: newSearchIndex(),
 	}
 }
 
-// Create сохраняет пользователя.
+// CascadeMemberships включает исключение пользователей из организаций
+// при удалении, как это делает PostgreSQL реализация.
+func (r *MemoryUserRepository) CascadeMemberships(memberships *MemoryMembershipRepository) {
+	r.memberships = memberships
+}
+
+// Ping проверяет доступность хранилища. In-memory хранилище доступно всегда.
+func (r *MemoryUserRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Create сохраняет пользователя в арендаторе из контекста.
 func (r *MemoryUserRepository) Create(ctx context.Context, user *models.User) error {
+	tenantID, all, err := tenant.Scope(ctx)
+	if err != nil || all {
+		return types.ErrTenantRequired
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.users[user.ID] = user
+	user.TenantID = tenantID
+
+	if r.emailTaken(user) {
+		return types.ErrUserAlreadyExists
+	}
+
+	stored := *user
+	r.users[user.ID] = &stored
+	r.index.add(&stored)
 
 	return nil
 }
 
-// Find возвращает пользователей по фильтру.
+// emailTaken проверяет, занят ли каноничный email другим неудалённым
+// пользователем того же арендатора. Повторяет уникальный индекс
+// idx_users_tenant_email_normalized в PostgreSQL.
+func (r *MemoryUserRepository) emailTaken(user *models.User) bool {
+	for _, other := range r.users {
+		if other.ID != user.ID && !other.IsDeleted() && other.TenantID == user.TenantID &&
+			other.EmailNormalized == user.EmailNormalized {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Find возвращает пользователей по фильтру, упорядоченных как в PostgreSQL
+// (см. models.CompareCursors).
 func (r *MemoryUserRepository) Find(ctx context.Context, filter models.UserFilter, pagination *models.Pagination) ([]*models.User, error) {
+	inTenant, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var filtered []*models.User
 
 	for _, user := range r.users {
-		if !r.matchesFilter(user, filter) {
+		if !inTenant(user.TenantID) || !r.matchesFilter(user, filter) {
 			continue
 		}
 
-		filtered = append(filtered, user)
+		if pagination != nil && pagination.After != nil &&
+			models.CompareCursors(models.CursorOf(user), pagination.After, pagination.Sort) <= 0 {
+			continue
+		}
+
+		found := *user
+		filtered = append(filtered, &found)
+	}
+
+	var orders []models.SortOrder
+	if pagination != nil {
+		orders = pagination.Sort
 	}
 
+	sort.Slice(filtered, func(i, j int) bool {
+		return models.CompareCursors(models.CursorOf(filtered[i]), models.CursorOf(filtered[j]), orders) < 0
+	})
+
 	if pagination == nil {
 		return filtered, nil
 	}
 
-	start := min(pagination.Offset, len(filtered))
+	start := 0
+	if pagination.After == nil {
+		start = min(pagination.Offset, len(filtered))
+	}
+
 	end := min(start+pagination.Limit, len(filtered))
 
 	return filtered[start:end], nil
 }
 
+// Search ищет пользователей по имени и email, самые релевантные первыми.
+// Возвращает страницу результатов и общее количество найденных.
+func (r *MemoryUserRepository) Search(ctx context.Context, query string, filter models.UserFilter, pagination *models.Pagination) ([]*models.User, int, error) {
+	inTenant, err := tenantScope(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := models.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+
+	scores := r.index.search(terms)
+	found := make([]*models.User, 0, len(scores))
+
+	for id := range scores {
+		user := r.users[id]
+		if !inTenant(user.TenantID) || !r.matchesFilter(user, filter) {
+			continue
+		}
+
+		copied := *user
+		found = append(found, &copied)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if si, sj := scores[found[i].ID], scores[found[j].ID]; si != sj {
+			return si > sj
+		}
+
+		return models.CompareCursors(models.CursorOf(found[i]), models.CursorOf(found[j]), nil) < 0
+	})
+
+	total := len(found)
+
+	if pagination != nil {
+		start := min(pagination.Offset, len(found))
+		end := min(start+pagination.Limit, len(found))
+		found = found[start:end]
+	}
+
+	return found, total, nil
+}
+
 // Count возвращает количество пользователей по фильтру.
 func (r *MemoryUserRepository) Count(ctx context.Context, filter models.UserFilter) (int, error) {
+	inTenant, err := tenantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	count := 0
 
 	for _, user := range r.users {
-		if r.matchesFilter(user, filter) {
+		if inTenant(user.TenantID) && r.matchesFilter(user, filter) {
 			count++
 		}
 	}
@@ -79,7 +206,7 @@ func (r *MemoryUserRepository) matchesFilter(user *models.User, filter models.Us
 		return false
 	}
 
-	if len(filter.Emails) > 0 && !containsString(filter.Emails, user.Email) {
+	if len(filter.Emails) > 0 && !containsString(filter.Emails, user.EmailNormalized) {
 		return false
 	}
 
@@ -87,6 +214,30 @@ func (r *MemoryUserRepository) matchesFilter(user *models.User, filter models.Us
 		return false
 	}
 
+	if containsStatus(filter.ExcludeStatuses, user.Status) {
+		return false
+	}
+
+	if len(filter.EmailDomains) > 0 && !containsFold(filter.EmailDomains, models.EmailDomain(user.Email)) {
+		return false
+	}
+
+	if filter.EmailMatch.IsSet() && !filter.EmailMatch.Matches(user.Email) {
+		return false
+	}
+
+	if filter.NameMatch.IsSet() && !filter.NameMatch.Matches(user.Name) {
+		return false
+	}
+
+	if !filter.CreatedAt.Contains(user.CreatedAt) || !filter.UpdatedAt.Contains(user.UpdatedAt) {
+		return false
+	}
+
+	if !filter.IncludeDeleted && user.IsDeleted() {
+		return false
+	}
+
 	return true
 }
 
@@ -100,6 +251,16 @@ func containsString(slice []string, val string) bool {
 	return false
 }
 
+func containsFold(slice []string, val string) bool {
+	for _, s := range slice {
+		if strings.EqualFold(s, val) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func containsStatus(slice []types.UserStatus, val types.UserStatus) bool {
 	for _, s := range slice {
 		if s == val {
@@ -110,35 +271,100 @@ func containsStatus(slice []types.UserStatus, val types.UserStatus) bool {
 	return false
 }
 
-// Update обновляет пользователя.
+// Update обновляет пользователя, если его версия совпадает с сохранённой.
+// При успехе версия пользователя увеличивается. Арендатор не меняется.
 func (r *MemoryUserRepository) Update(ctx context.Context, user *models.User) error {
+	inTenant, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.users[user.ID]; !ok {
+	existing, ok := r.users[user.ID]
+	if !ok || !inTenant(existing.TenantID) {
 		return types.ErrUserNotFound
 	}
 
-	r.users[user.ID] = user
+	user.TenantID = existing.TenantID
+
+	if existing.Version != user.Version {
+		return types.ErrVersionConflict
+	}
+
+	if !user.IsDeleted() && r.emailTaken(user) {
+		return types.ErrUserAlreadyExists
+	}
+
+	user.Version++
+
+	stored := *user
+	r.users[user.ID] = &stored
+	r.index.add(&stored)
 
 	return nil
 }
 
-// Delete удаляет пользователей по фильтру. Возвращает количество удалённых.
-func (r *MemoryUserRepository) Delete(ctx context.Context, filter models.UserFilter) (int, error) {
+// Delete мягко удаляет пользователей по фильтру и, если включено
+// CascadeMemberships, исключает их из организаций. Возвращает количество
+// удалённых. Уже удалённые пользователи не затрагиваются.
+func (r *MemoryUserRepository) Delete(ctx context.Context, filter models.UserFilter, deletedAt time.Time) (int, error) {
+	inTenant, err := tenantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filter.IncludeDeleted = false
+
+	var deleted []string
+
+	for _, user := range r.users {
+		if !inTenant(user.TenantID) || !r.matchesFilter(user, filter) {
+			continue
+		}
+
+		at := deletedAt
+		user.DeletedAt = &at
+		user.UpdatedAt = deletedAt
+		user.Version++
+		deleted = append(deleted, user.ID)
+	}
+
+	// Пустой фильтр членства совпал бы со всеми записями.
+	if r.memberships != nil && len(deleted) > 0 {
+		if _, err := r.memberships.Remove(ctx, models.MembershipFilter{UserIDs: deleted}); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(deleted), nil
+}
+
+// Purge физически удаляет пользователей, удалённых раньше deletedBefore.
+func (r *MemoryUserRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	inTenant, err := tenantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	var toDelete []string
 
 	for id, user := range r.users {
-		if r.matchesFilter(user, filter) {
+		if inTenant(user.TenantID) && user.IsDeleted() && user.DeletedAt.Before(deletedBefore) {
 			toDelete = append(toDelete, id)
 		}
 	}
 
 	for _, id := range toDelete {
 		delete(r.users, id)
+		r.index.remove(id)
 	}
 
 	return len(toDelete), nil
@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+func TestMemoryUserRepository_Search(t *testing.T) {
+	repo := NewMemoryUserRepository()
+	ctx := tenant.WithID(context.Background(), "acme")
+	now := time.Now()
+
+	users := []*models.User{
+		{ID: "1", Email: "smith@example.com", EmailNormalized: "smith@example.com", Name: "John Doe", Status: types.UserStatusActive, CreatedAt: now},
+		{ID: "2", Email: "jane@example.com", EmailNormalized: "jane@example.com", Name: "Jane Smith", Status: types.UserStatusActive, CreatedAt: now},
+		{ID: "3", Email: "smithers@acme.com", EmailNormalized: "smithers@acme.com", Name: "Waylon Smithers", Status: types.UserStatusBlocked, CreatedAt: now},
+		{ID: "4", Email: "bob@acme.com", EmailNormalized: "bob@acme.com", Name: "Bob", Status: types.UserStatusActive, CreatedAt: now},
+	}
+
+	for _, u := range users {
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		filter models.UserFilter
+		want   []string
+	}{
+		// Совпадение в имени весит больше, чем в email.
+		{name: "ranked by field", query: "smith", want: []string{"3", "2", "1"}},
+		{name: "all terms required", query: "smith acme", want: []string{"3"}},
+		{name: "status filter", query: "smith", filter: models.UserFilter{Statuses: []types.UserStatus{types.UserStatusActive}}, want: []string{"2", "1"}},
+		{name: "no match", query: "nobody", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, total, err := repo.Search(ctx, tt.query, tt.filter, nil)
+			if err != nil {
+				t.Fatalf("Search() unexpected error = %v", err)
+			}
+
+			if total != len(tt.want) {
+				t.Fatalf("Search() total = %d, want %d", total, len(tt.want))
+			}
+
+			for i, u := range found {
+				if u.ID != tt.want[i] {
+					t.Errorf("Search()[%d] = %s, want %s", i, u.ID, tt.want[i])
+				}
+			}
+		})
+	}
+
+	// Переименование переиндексирует пользователя.
+	renamed := *users[3]
+	renamed.Name = "Bob Smith"
+	if err := repo.Update(ctx, &renamed); err != nil {
+		t.Fatalf("Update() unexpected error = %v", err)
+	}
+
+	if _, total, _ := repo.Search(ctx, "bob smith", models.UserFilter{}, nil); total != 1 {
+		t.Errorf("Search() after rename total = %d, want 1", total)
+	}
+}
+
+func TestMemoryUserRepository_Tenant(t *testing.T) {
+	repo := NewMemoryUserRepository()
+	acme := tenant.WithID(context.Background(), "acme")
+	globex := tenant.WithID(context.Background(), "globex")
+
+	newUser := func(id string) *models.User {
+		return &models.User{ID: id, Email: "bob@example.com", EmailNormalized: "bob@example.com", Status: types.UserStatusActive}
+	}
+
+	if err := repo.Create(context.Background(), newUser("0")); !errors.Is(err, types.ErrTenantRequired) {
+		t.Errorf("Create() without tenant error = %v, want %v", err, types.ErrTenantRequired)
+	}
+
+	if err := repo.Create(acme, newUser("1")); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	// Email уникален только в пределах арендатора.
+	if err := repo.Create(acme, newUser("2")); !errors.Is(err, types.ErrUserAlreadyExists) {
+		t.Errorf("Create() duplicate email error = %v, want %v", err, types.ErrUserAlreadyExists)
+	}
+
+	if err := repo.Create(globex, newUser("3")); err != nil {
+		t.Fatalf("Create() same email in other tenant unexpected error = %v", err)
+	}
+
+	found, err := repo.Find(globex, models.UserFilter{IDs: []string{"1", "3"}}, nil)
+	if err != nil {
+		t.Fatalf("Find() unexpected error = %v", err)
+	}
+
+	if len(found) != 1 || found[0].ID != "3" || found[0].TenantID != "globex" {
+		t.Errorf("Find() in globex = %+v, want only user 3", found)
+	}
+
+	if count, _ := repo.Count(tenant.WithAll(context.Background()), models.UserFilter{}); count != 2 {
+		t.Errorf("Count() over all tenants = %d, want 2", count)
+	}
+
+	// Пользователь другого арендатора недоступен для изменения и удаления.
+	if err := repo.Update(globex, newUser("1")); !errors.Is(err, types.ErrUserNotFound) {
+		t.Errorf("Update() other tenant error = %v, want %v", err, types.ErrUserNotFound)
+	}
+
+	if deleted, _ := repo.Delete(globex, models.UserFilter{IDs: []string{"1"}}, time.Now()); deleted != 0 {
+		t.Errorf("Delete() other tenant deleted = %d, want 0", deleted)
+	}
+}
+
+func TestMemoryUserRepository_DeleteRemovesMemberships(t *testing.T) {
+	memberships := NewMemoryMembershipRepository()
+	repo := NewMemoryUserRepository()
+	repo.CascadeMemberships(memberships)
+	ctx := tenant.WithID(context.Background(), "acme")
+	now := time.Now()
+
+	for _, u := range []*models.User{
+		{ID: "alice", Email: "alice@example.com", EmailNormalized: "alice@example.com", CreatedAt: now},
+		{ID: "bob", Email: "bob@example.com", EmailNormalized: "bob@example.com", CreatedAt: now},
+	} {
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+	}
+
+	for _, m := range []*models.Membership{
+		{OrganizationID: "org-1", UserID: "alice", Role: types.MemberRoleOwner, CreatedAt: now},
+		{OrganizationID: "org-1", UserID: "bob", Role: types.MemberRoleMember, CreatedAt: now},
+		{OrganizationID: "org-2", UserID: "alice", Role: types.MemberRoleMember, CreatedAt: now},
+	} {
+		if err := memberships.Add(ctx, m); err != nil {
+			t.Fatalf("Add() unexpected error = %v", err)
+		}
+	}
+
+	if count, err := repo.Delete(ctx, models.UserFilter{IDs: []string{"alice"}}, now); err != nil || count != 1 {
+		t.Fatalf("Delete() = %d, %v, want 1", count, err)
+	}
+
+	remaining, _ := memberships.Find(ctx, models.MembershipFilter{})
+	if len(remaining) != 1 || remaining[0].UserID != "bob" {
+		t.Errorf("memberships after delete = %+v, want only bob", remaining)
+	}
+
+	// Повторное удаление никого не затрагивает и не трогает чужое членство.
+	if count, err := repo.Delete(ctx, models.UserFilter{IDs: []string{"alice"}}, now); err != nil || count != 0 {
+		t.Errorf("Delete() twice = %d, %v, want 0", count, err)
+	}
+
+	if remaining, _ := memberships.Find(ctx, models.MembershipFilter{}); len(remaining) != 1 {
+		t.Errorf("memberships after repeated delete = %d, want 1", len(remaining))
+	}
+}
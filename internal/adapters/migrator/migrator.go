@@ -0,0 +1,349 @@
+// Package migrator применяет SQL миграции схемы БД.
+//
+// Версия хранится в таблице schema_migrations (version, dirty) в том же
+// формате, что и у golang-migrate, поэтому базы, размеченные этим
+// инструментом, продолжают работать без изменений.
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// lockKey - ключ advisory lock, под которым выполняются миграции.
+// Не даёт нескольким подам мигрировать одну базу одновременно.
+const lockKey = 7264184213
+
+// ErrDirty возвращается, если предыдущая миграция завершилась с ошибкой
+// и версию нужно выставить вручную через Force.
+var ErrDirty = errors.New("database is in dirty state, fix it and run force")
+
+var fileRegex = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration - одна миграция схемы.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status - текущее состояние схемы.
+type Status struct {
+	Version    uint64
+	Dirty      bool
+	Migrations []Migration
+}
+
+// Pending возвращает миграции, которые ещё не применены.
+func (s Status) Pending() []Migration {
+	var pending []Migration
+
+	for _, m := range s.Migrations {
+		if m.Version > s.Version {
+			pending = append(pending, m)
+		}
+	}
+
+	return pending
+}
+
+// Migrator - применяет миграции к PostgreSQL.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New создаёт мигратор с миграциями из fsys.
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load читает миграции из корня fsys и сортирует их по версии.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+
+	for _, entry := range entries {
+		match := fileRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse version of %s: %w", entry.Name(), err)
+		}
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up применяет все неприменённые миграции. Возвращает количество применённых.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration.Up, migration.Version, migration.Version); err != nil {
+				return fmt.Errorf("apply %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			applied++
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down откатывает n последних применённых миграций. Возвращает количество откаченных.
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	reverted := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && reverted < n; i-- {
+			migration := m.migrations[i]
+			if migration.Version > version {
+				continue
+			}
+
+			var previous uint64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+
+			if err := m.apply(ctx, conn, migration.Down, migration.Version, previous); err != nil {
+				return fmt.Errorf("revert %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			reverted++
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Force выставляет версию схемы без выполнения миграций и снимает флаг dirty.
+// Версия 0 означает "ни одна миграция не применена".
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("begin: %w", err)
+		}
+		defer tx.Rollback()
+
+		if err := setVersion(ctx, tx, version, false); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
+
+// Status возвращает текущую версию схемы и список известных миграций.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	status := Status{Migrations: m.migrations}
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return status, fmt.Errorf("get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return status, err
+	}
+
+	status.Version, status.Dirty, err = readVersion(ctx, conn)
+
+	return status, err
+}
+
+// withLock выполняет fn на отдельном подключении под advisory lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+
+	defer func() {
+		// Контекст мог быть отменён, а лок нужно отпустить в любом случае.
+		_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+	}()
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// cleanVersion возвращает текущую версию или ErrDirty.
+func (m *Migrator) cleanVersion(ctx context.Context, conn *sql.Conn) (uint64, error) {
+	version, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	if dirty {
+		return 0, fmt.Errorf("version %d: %w", version, ErrDirty)
+	}
+
+	return version, nil
+}
+
+// apply выполняет SQL миграции и записывает новую версию в одной транзакции.
+// Перед этим версия выполняемой миграции отдельно фиксируется как dirty:
+// если миграция упала или процесс прервался, флаг останется и следующий
+// запуск вернёт ErrDirty. Транзакция к этому моменту откатана, поэтому схема
+// остаётся на прежней версии - её и нужно выставить через Force.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, query string, running, version uint64) error {
+	if err := markDirty(ctx, conn, running); err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if query != "" {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	if err := setVersion(ctx, tx, version, false); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// markDirty фиксирует version с флагом dirty в отдельной транзакции.
+func markDirty(ctx context.Context, conn *sql.Conn, version uint64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := setVersion(ctx, tx, version, true); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func ensureTable(ctx context.Context, conn *sql.Conn) error {
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		dirty BOOLEAN NOT NULL
+	)`
+
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return nil
+}
+
+func readVersion(ctx context.Context, conn *sql.Conn) (version uint64, dirty bool, err error) {
+	err = conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+func setVersion(ctx context.Context, tx *sql.Tx, version uint64, dirty bool) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return fmt.Errorf("reset schema version: %w", err)
+	}
+
+	if version == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, version, dirty); err != nil {
+		return fmt.Errorf("write schema version: %w", err)
+	}
+
+	return nil
+}
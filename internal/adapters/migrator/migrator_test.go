@@ -0,0 +1,62 @@
+package migrator
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/obsessed-gopher/micro-service-guide/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_second.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+		"000002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+		"000001_first.up.sql":    {Data: []byte("CREATE TABLE a ();")},
+		"000001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+		"migrations.go":          {Data: []byte("package migrations")},
+	}
+
+	got, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Load() len = %d, want 2", len(got))
+	}
+
+	if got[0].Version != 1 || got[0].Name != "first" || got[0].Down != "DROP TABLE a;" {
+		t.Errorf("Load()[0] = %+v", got[0])
+	}
+
+	if got[1].Version != 2 || got[1].Up != "CREATE TABLE b ();" {
+		t.Errorf("Load()[1] = %+v", got[1])
+	}
+}
+
+func TestLoad_MissingUp(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_first.down.sql": {Data: []byte("DROP TABLE a;")},
+	}
+
+	if _, err := Load(fsys); err == nil {
+		t.Error("Load() expected error for migration without up file")
+	}
+}
+
+func TestLoad_Embedded(t *testing.T) {
+	got, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	for i, m := range got {
+		if m.Down == "" {
+			t.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+		}
+
+		if uint64(i+1) != m.Version {
+			t.Errorf("migration versions are not sequential: got %d at position %d", m.Version, i)
+		}
+	}
+}
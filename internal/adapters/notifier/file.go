@@ -0,0 +1,81 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// message - уведомление в файле, по одному JSON объекту на строку.
+type message struct {
+	Type      string    `json:"type"`
+	UserID    string    `json:"user_id"`
+	To        string    `json:"to"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// FileNotifier дописывает уведомления в файл в формате JSON Lines.
+// Удобен для локальных и интеграционных тестов: письма можно прочитать из файла.
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier создаёт новый нотификатор в файл.
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+// SendPasswordReset записывает токен сброса пароля в файл.
+func (n *FileNotifier) SendPasswordReset(_ context.Context, user *models.User, token string, expiresAt time.Time) error {
+	return n.write(message{
+		Type:      "password_reset",
+		UserID:    user.ID,
+		To:        user.Email,
+		Token:     token,
+		ExpiresAt: expiresAt,
+		SentAt:    time.Now(),
+	})
+}
+
+// SendEmailVerification записывает токен подтверждения email в файл.
+func (n *FileNotifier) SendEmailVerification(_ context.Context, user *models.User, email, token string, expiresAt time.Time) error {
+	return n.write(message{
+		Type:      "email_verification",
+		UserID:    user.ID,
+		To:        email,
+		Token:     token,
+		ExpiresAt: expiresAt,
+		SentAt:    time.Now(),
+	})
+}
+
+// write дописывает сообщение в конец файла.
+func (n *FileNotifier) write(msg message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal notification: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open notification file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write notification: %w", err)
+	}
+
+	return nil
+}
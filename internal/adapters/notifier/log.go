@@ -0,0 +1,45 @@
+// Package notifier содержит доставку уведомлений пользователям для локального запуска.
+package notifier
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// LogNotifier пишет уведомления в лог вместо отправки письма.
+// Токены попадают в лог открытым текстом, поэтому только для разработки.
+type LogNotifier struct {
+	log *slog.Logger
+}
+
+// NewLogNotifier создаёт новый нотификатор в лог.
+func NewLogNotifier(log *slog.Logger) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+// SendPasswordReset логирует токен сброса пароля.
+func (n *LogNotifier) SendPasswordReset(ctx context.Context, user *models.User, token string, expiresAt time.Time) error {
+	n.log.InfoContext(ctx, "password reset notification",
+		slog.String("user_id", user.ID),
+		slog.String("to", user.Email),
+		slog.String("token", token),
+		slog.Time("expires_at", expiresAt),
+	)
+
+	return nil
+}
+
+// SendEmailVerification логирует токен подтверждения email.
+func (n *LogNotifier) SendEmailVerification(ctx context.Context, user *models.User, email, token string, expiresAt time.Time) error {
+	n.log.InfoContext(ctx, "email verification notification",
+		slog.String("user_id", user.ID),
+		slog.String("to", email),
+		slog.String("token", token),
+		slog.Time("expires_at", expiresAt),
+	)
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package password содержит политику сложности паролей.
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// minPersonalTermLength - более короткие части email и имени не проверяются,
+// иначе под запрет попадают случайные совпадения вроде "al" или "bo".
+const minPersonalTermLength = 4
+
+// Rules - правила политики паролей. Минимальная длина считается в символах,
+// максимальная - в байтах UTF-8: bcrypt отклоняет пароли длиннее 72 байт,
+// и пароль из многобайтовых символов не должен проходить политику,
+// а затем падать при хэшировании.
+type Rules struct {
+	MinLength          int
+	MaxLength          int // в байтах, 0 - без ограничения
+	RequireLowercase   bool
+	RequireUppercase   bool
+	RequireDigit       bool
+	RequireSymbol      bool
+	ForbidPersonalInfo bool // пароль не должен содержать email или имя
+}
+
+// Policy проверяет пароль по правилам и списку распространённых
+// или утёкших паролей.
+type Policy struct {
+	rules    Rules
+	breached map[string]struct{}
+}
+
+// NewPolicy создаёт политику. Пароли из breached сравниваются без учёта регистра.
+func NewPolicy(rules Rules, breached []string) *Policy {
+	set := make(map[string]struct{}, len(breached))
+	for _, p := range breached {
+		set[strings.ToLower(p)] = struct{}{}
+	}
+
+	return &Policy{rules: rules, breached: set}
+}
+
+// LoadList читает список паролей из файла: один пароль на строку,
+// пустые строки и строки, начинающиеся с "#", пропускаются.
+func LoadList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open password list: %w", err)
+	}
+	defer f.Close()
+
+	var list []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		list = append(list, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read password list: %w", err)
+	}
+
+	return list, nil
+}
+
+// Validate проверяет пароль и возвращает *types.PasswordPolicyError
+// со всеми найденными нарушениями. personal - email и имя пользователя.
+func (p *Policy) Validate(password string, personal ...string) error {
+	var violations []types.PasswordViolation
+
+	add := func(reason, format string, args ...any) {
+		violations = append(violations, types.PasswordViolation{
+			Reason:  reason,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if utf8.RuneCountInString(password) < p.rules.MinLength {
+		add(types.PasswordTooShort, "must be at least %d characters long", p.rules.MinLength)
+	}
+	if p.rules.MaxLength > 0 && len(password) > p.rules.MaxLength {
+		add(types.PasswordTooLong, "must be at most %d bytes long", p.rules.MaxLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	if p.rules.RequireLowercase && !lower {
+		add(types.PasswordNoLowercase, "must contain a lowercase letter")
+	}
+	if p.rules.RequireUppercase && !upper {
+		add(types.PasswordNoUppercase, "must contain an uppercase letter")
+	}
+	if p.rules.RequireDigit && !digit {
+		add(types.PasswordNoDigit, "must contain a digit")
+	}
+	if p.rules.RequireSymbol && !symbol {
+		add(types.PasswordNoSymbol, "must contain a symbol")
+	}
+
+	if p.rules.ForbidPersonalInfo && containsPersonalInfo(password, personal) {
+		add(types.PasswordPersonalInfo, "must not contain your email or name")
+	}
+
+	if _, ok := p.breached[strings.ToLower(password)]; ok {
+		add(types.PasswordCommonOrLeaked, "is too common or has appeared in a data breach")
+	}
+
+	if len(violations) > 0 {
+		return &types.PasswordPolicyError{Violations: violations}
+	}
+
+	return nil
+}
+
+// containsPersonalInfo проверяет, входит ли в пароль локальная часть email,
+// имя или их части.
+func containsPersonalInfo(password string, personal []string) bool {
+	password = strings.ToLower(password)
+
+	for _, value := range personal {
+		if at := strings.LastIndexByte(value, '@'); at >= 0 {
+			value = value[:at]
+		}
+
+		terms := append(models.SearchTerms(value), strings.ToLower(strings.TrimSpace(value)))
+		for _, term := range terms {
+			if utf8.RuneCountInString(term) >= minPersonalTermLength && strings.Contains(password, term) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
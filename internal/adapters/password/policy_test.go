@@ -0,0 +1,68 @@
+package password
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	policy := NewPolicy(Rules{
+		MinLength:          10,
+		MaxLength:          64,
+		RequireLowercase:   true,
+		RequireUppercase:   true,
+		RequireDigit:       true,
+		RequireSymbol:      true,
+		ForbidPersonalInfo: true,
+	}, []string{"Correct-Horse-Battery-1"})
+
+	tests := []struct {
+		name     string
+		password string
+		want     []string
+	}{
+		{name: "valid", password: "Tr0ub4dor&3x"},
+		{name: "short without classes", password: "abc", want: []string{
+			types.PasswordTooShort, types.PasswordNoUppercase, types.PasswordNoDigit, types.PasswordNoSymbol,
+		}},
+		{name: "contains name", password: "Smithsonian-42", want: []string{types.PasswordPersonalInfo}},
+		{name: "contains email local part", password: "X-Alice.Smith-1", want: []string{types.PasswordPersonalInfo}},
+		// 39 символов, из них 37 кириллических, занимают 76 байт.
+		{name: "multibyte over max bytes", password: "Ж" + strings.Repeat("ж", 36) + "-1", want: []string{
+			types.PasswordTooLong,
+		}},
+		{name: "breached", password: "correct-horse-battery-1", want: []string{
+			types.PasswordNoUppercase, types.PasswordCommonOrLeaked,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, "alice.smith@acme.com", "Alice Smith")
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+
+			var policyErr *types.PasswordPolicyError
+			if !errors.As(err, &policyErr) || !errors.Is(err, types.ErrInvalidPassword) {
+				t.Fatalf("Validate() error = %v, want PasswordPolicyError", err)
+			}
+
+			reasons := make([]string, len(policyErr.Violations))
+			for i, v := range policyErr.Violations {
+				reasons[i] = v.Reason
+			}
+
+			if !slices.Equal(reasons, tt.want) {
+				t.Errorf("Validate() reasons = %v, want %v", reasons, tt.want)
+			}
+		})
+	}
+}
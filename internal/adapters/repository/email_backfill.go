@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// EmailRecord - email пользователя и его сохранённая каноничная форма.
+type EmailRecord struct {
+	ID         string
+	TenantID   string
+	Email      string
+	Normalized string
+	Deleted    bool
+}
+
+// PostgresEmailBackfill пересчитывает email_normalized существующих
+// пользователей во всех арендаторах.
+type PostgresEmailBackfill struct {
+	db *sql.DB
+}
+
+// NewPostgresEmailBackfill создаёт пересчёт каноничных email.
+func NewPostgresEmailBackfill(db *sql.DB) *PostgresEmailBackfill {
+	return &PostgresEmailBackfill{db: db}
+}
+
+// Find возвращает до limit пользователей с ID больше afterID в порядке ID.
+func (r *PostgresEmailBackfill) Find(ctx context.Context, afterID string, limit int) ([]EmailRecord, error) {
+	query := `
+		SELECT id, tenant_id, email, email_normalized, deleted_at IS NOT NULL
+		FROM users WHERE id > $1 ORDER BY id LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query emails: %w", err)
+	}
+	defer rows.Close()
+
+	var records []EmailRecord
+
+	for rows.Next() {
+		var record EmailRecord
+		if err := rows.Scan(&record.ID, &record.TenantID, &record.Email, &record.Normalized, &record.Deleted); err != nil {
+			return nil, fmt.Errorf("scan email: %w", err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// SetNormalized сохраняет новые каноничные email (ID -> email) в одной транзакции.
+// Версия и updated_at не меняются: данные пользователя остаются прежними.
+//
+// Уникальный индекс проверяется сразу для каждой строки, поэтому пользователи,
+// обменявшиеся каноничными адресами, не обновляются по одному. Сначала всем
+// изменяемым записям ставится уникальная временная метка по ID, затем
+// новые значения записываются одним запросом.
+func (r *PostgresEmailBackfill) SetNormalized(ctx context.Context, normalized map[string]string) error {
+	ids := make([]string, 0, len(normalized))
+	emails := make([]string, 0, len(normalized))
+
+	for id, email := range normalized {
+		ids = append(ids, id)
+		emails = append(emails, email)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	clear := `UPDATE users SET email_normalized = 'backfill:' || id WHERE id = ANY($1)`
+
+	if _, err := tx.ExecContext(ctx, clear, pq.Array(ids)); err != nil {
+		return fmt.Errorf("clear normalized emails: %w", err)
+	}
+
+	set := `
+		UPDATE users AS u SET email_normalized = v.email
+		FROM unnest($1::text[], $2::text[]) AS v(id, email)
+		WHERE u.id = v.id
+	`
+
+	if _, err := tx.ExecContext(ctx, set, pq.Array(ids), pq.Array(emails)); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("set normalized emails: email taken by a user changed after the check, run normalize-emails check again: %w", err)
+		}
+		return fmt.Errorf("set normalized emails: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	return nil
+}
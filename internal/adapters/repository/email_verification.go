@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// PostgresEmailVerificationRepository - PostgreSQL реализация репозитория токенов подтверждения email.
+type PostgresEmailVerificationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresEmailVerificationRepository создаёт новый PostgreSQL репозиторий токенов подтверждения email.
+func NewPostgresEmailVerificationRepository(db *sql.DB) *PostgresEmailVerificationRepository {
+	return &PostgresEmailVerificationRepository{db: db}
+}
+
+// Create сохраняет токен подтверждения email в БД.
+func (r *PostgresEmailVerificationRepository) Create(ctx context.Context, verification *models.EmailVerification) error {
+	query := `
+		INSERT INTO email_verifications (id, user_id, email, email_normalized, token_hash,
+			created_at, expires_at, used_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		verification.ID, verification.UserID, verification.Email, verification.EmailNormalized,
+		verification.TokenHash, verification.CreatedAt, verification.ExpiresAt, verification.UsedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("insert email verification: %w", err)
+	}
+
+	return nil
+}
+
+// Find возвращает токены подтверждения email по фильтру, новые первыми.
+func (r *PostgresEmailVerificationRepository) Find(ctx context.Context, filter models.EmailVerificationFilter) ([]*models.EmailVerification, error) {
+	qb := newQueryBuilder()
+	qb.buildEmailVerificationFilter(filter)
+
+	query := `SELECT id, user_id, email, email_normalized, token_hash,
+		created_at, expires_at, used_at FROM email_verifications` +
+		qb.whereClause() +
+		` ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, qb.args...)
+	if err != nil {
+		return nil, fmt.Errorf("query email verifications: %w", err)
+	}
+	defer rows.Close()
+
+	var verifications []*models.EmailVerification
+
+	for rows.Next() {
+		v := &models.EmailVerification{}
+		if err := rows.Scan(
+			&v.ID, &v.UserID, &v.Email, &v.EmailNormalized, &v.TokenHash,
+			&v.CreatedAt, &v.ExpiresAt, &v.UsedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan email verification: %w", err)
+		}
+
+		verifications = append(verifications, v)
+	}
+
+	return verifications, rows.Err()
+}
+
+// Count возвращает количество токенов подтверждения email по фильтру.
+func (r *PostgresEmailVerificationRepository) Count(ctx context.Context, filter models.EmailVerificationFilter) (int, error) {
+	qb := newQueryBuilder()
+	qb.buildEmailVerificationFilter(filter)
+
+	var count int
+
+	query := `SELECT COUNT(*) FROM email_verifications` + qb.whereClause()
+	if err := r.db.QueryRowContext(ctx, query, qb.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count email verifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// Use помечает неиспользованные токены по фильтру использованными.
+// Возвращает количество помеченных.
+func (r *PostgresEmailVerificationRepository) Use(ctx context.Context, filter models.EmailVerificationFilter, usedAt time.Time) (int, error) {
+	qb := newQueryBuilder()
+	set := `UPDATE email_verifications SET used_at = ` + qb.addArg(usedAt)
+
+	qb.buildEmailVerificationFilter(filter)
+	qb.addRawCondition("used_at IS NULL")
+
+	result, err := r.db.ExecContext(ctx, set+qb.whereClause(), qb.args...)
+	if err != nil {
+		return 0, fmt.Errorf("use email verifications: %w", err)
+	}
+
+	count, _ := result.RowsAffected()
+
+	return int(count), nil
+}
+
+// buildEmailVerificationFilter применяет фильтр токенов подтверждения email к query builder.
+func (qb *queryBuilder) buildEmailVerificationFilter(filter models.EmailVerificationFilter) {
+	if len(filter.IDs) > 0 {
+		qb.addInCondition("id", toAnySlice(filter.IDs))
+	}
+
+	if len(filter.UserIDs) > 0 {
+		qb.addInCondition("user_id", toAnySlice(filter.UserIDs))
+	}
+
+	if len(filter.TokenHashes) > 0 {
+		qb.addInCondition("token_hash", toAnySlice(filter.TokenHashes))
+	}
+
+	if filter.CreatedAfter != nil {
+		qb.addComparison("created_at", ">", *filter.CreatedAfter)
+	}
+
+	if filter.ActiveAt != nil {
+		qb.addRawCondition("used_at IS NULL")
+		qb.addComparison("expires_at", ">", *filter.ActiveAt)
+	}
+}
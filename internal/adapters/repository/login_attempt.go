@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// PostgresLoginAttemptRepository - PostgreSQL реализация хранилища счётчиков неудачных попыток входа.
+type PostgresLoginAttemptRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLoginAttemptRepository создаёт новое PostgreSQL хранилище счётчиков неудачных попыток входа.
+func NewPostgresLoginAttemptRepository(db *sql.DB) *PostgresLoginAttemptRepository {
+	return &PostgresLoginAttemptRepository{db: db}
+}
+
+// Find возвращает счётчики по ключам. Ключи без ошибок пропускаются.
+func (r *PostgresLoginAttemptRepository) Find(ctx context.Context, keys []string) ([]*models.LoginFailures, error) {
+	query := `SELECT key, count, last_failure_at FROM login_failures WHERE key = ANY($1)`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(keys))
+	if err != nil {
+		return nil, fmt.Errorf("query login failures: %w", err)
+	}
+	defer rows.Close()
+
+	var failures []*models.LoginFailures
+
+	for rows.Next() {
+		f := &models.LoginFailures{}
+		if err := rows.Scan(&f.Key, &f.Count, &f.LastFailureAt); err != nil {
+			return nil, fmt.Errorf("scan login failures: %w", err)
+		}
+
+		failures = append(failures, f)
+	}
+
+	return failures, rows.Err()
+}
+
+// AddFailure атомарно увеличивает счётчик ключа и возвращает его новое состояние.
+// Если последняя ошибка была раньше resetBefore, счёт начинается заново.
+func (r *PostgresLoginAttemptRepository) AddFailure(ctx context.Context, key string, at, resetBefore time.Time) (*models.LoginFailures, error) {
+	query := `
+		INSERT INTO login_failures (key, count, last_failure_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN login_failures.last_failure_at < $3 THEN 1 ELSE login_failures.count + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING key, count, last_failure_at
+	`
+
+	f := &models.LoginFailures{}
+
+	if err := r.db.QueryRowContext(ctx, query, key, at, resetBefore).Scan(&f.Key, &f.Count, &f.LastFailureAt); err != nil {
+		return nil, fmt.Errorf("add login failure: %w", err)
+	}
+
+	return f, nil
+}
+
+// RemoveFailure атомарно уменьшает счётчики ключей на одну попытку.
+func (r *PostgresLoginAttemptRepository) RemoveFailure(ctx context.Context, keys []string) error {
+	query := `UPDATE login_failures SET count = count - 1 WHERE key = ANY($1) AND count > 0`
+
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(keys)); err != nil {
+		return fmt.Errorf("remove login failure: %w", err)
+	}
+
+	return nil
+}
+
+// Reset удаляет счётчики по ключам.
+func (r *PostgresLoginAttemptRepository) Reset(ctx context.Context, keys []string) error {
+	query := `DELETE FROM login_failures WHERE key = ANY($1)`
+
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(keys)); err != nil {
+		return fmt.Errorf("reset login failures: %w", err)
+	}
+
+	return nil
+}
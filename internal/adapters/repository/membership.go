@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// PostgresMembershipRepository - PostgreSQL реализация хранилища членства в организациях.
+type PostgresMembershipRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMembershipRepository создаёт новое PostgreSQL хранилище членства в организациях.
+func NewPostgresMembershipRepository(db *sql.DB) *PostgresMembershipRepository {
+	return &PostgresMembershipRepository{db: db}
+}
+
+// Add добавляет участника. Если пользователь уже состоит
+// в организации, возвращает types.ErrMemberAlreadyExists.
+func (r *PostgresMembershipRepository) Add(ctx context.Context, membership *models.Membership) error {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		membership.OrganizationID, membership.UserID, membership.Role, membership.CreatedAt,
+	)
+
+	if isUniqueViolation(err) {
+		return types.ErrMemberAlreadyExists
+	}
+
+	if err != nil {
+		return fmt.Errorf("insert membership: %w", err)
+	}
+
+	return nil
+}
+
+// Find возвращает членство по фильтру в порядке добавления.
+func (r *PostgresMembershipRepository) Find(ctx context.Context, filter models.MembershipFilter) ([]*models.Membership, error) {
+	qb := newQueryBuilder()
+	qb.buildMembershipFilter(filter)
+
+	query := `SELECT organization_id, user_id, role, created_at FROM organization_members` +
+		qb.whereClause() +
+		` ORDER BY created_at, user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, qb.args...)
+	if err != nil {
+		return nil, fmt.Errorf("query memberships: %w", err)
+	}
+	defer rows.Close()
+
+	var memberships []*models.Membership
+
+	for rows.Next() {
+		membership := &models.Membership{}
+		if err := rows.Scan(
+			&membership.OrganizationID, &membership.UserID, &membership.Role, &membership.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan membership: %w", err)
+		}
+
+		memberships = append(memberships, membership)
+	}
+
+	return memberships, rows.Err()
+}
+
+// Remove удаляет членство по фильтру. Возвращает количество удалённых.
+func (r *PostgresMembershipRepository) Remove(ctx context.Context, filter models.MembershipFilter) (int, error) {
+	qb := newQueryBuilder()
+	qb.buildMembershipFilter(filter)
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM organization_members`+qb.whereClause(), qb.args...)
+	if err != nil {
+		return 0, fmt.Errorf("delete memberships: %w", err)
+	}
+
+	count, _ := result.RowsAffected()
+
+	return int(count), nil
+}
+
+// buildMembershipFilter применяет фильтр членства к query builder.
+func (qb *queryBuilder) buildMembershipFilter(filter models.MembershipFilter) {
+	if len(filter.OrganizationIDs) > 0 {
+		qb.addInCondition("organization_id", toAnySlice(filter.OrganizationIDs))
+	}
+
+	if len(filter.UserIDs) > 0 {
+		qb.addInCondition("user_id", toAnySlice(filter.UserIDs))
+	}
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// PostgresMFAChallengeRepository - PostgreSQL реализация репозитория токенов второго шага входа.
+type PostgresMFAChallengeRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMFAChallengeRepository создаёт новый PostgreSQL репозиторий токенов второго шага входа.
+func NewPostgresMFAChallengeRepository(db *sql.DB) *PostgresMFAChallengeRepository {
+	return &PostgresMFAChallengeRepository{db: db}
+}
+
+// Create сохраняет токен второго шага входа в БД.
+func (r *PostgresMFAChallengeRepository) Create(ctx context.Context, challenge *models.MFAChallenge) error {
+	query := `
+		INSERT INTO mfa_challenges (id, user_id, token_hash, attempts, created_at, expires_at, used_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		challenge.ID, challenge.UserID, challenge.TokenHash, challenge.Attempts,
+		challenge.CreatedAt, challenge.ExpiresAt, challenge.UsedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("insert mfa challenge: %w", err)
+	}
+
+	return nil
+}
+
+// Find возвращает токены второго шага входа по фильтру, новые первыми.
+func (r *PostgresMFAChallengeRepository) Find(ctx context.Context, filter models.MFAChallengeFilter) ([]*models.MFAChallenge, error) {
+	qb := newQueryBuilder()
+	qb.buildMFAChallengeFilter(filter)
+
+	query := `SELECT id, user_id, token_hash, attempts, created_at, expires_at, used_at
+		FROM mfa_challenges` +
+		qb.whereClause() +
+		` ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, qb.args...)
+	if err != nil {
+		return nil, fmt.Errorf("query mfa challenges: %w", err)
+	}
+	defer rows.Close()
+
+	var challenges []*models.MFAChallenge
+
+	for rows.Next() {
+		c := &models.MFAChallenge{}
+		if err := rows.Scan(
+			&c.ID, &c.UserID, &c.TokenHash, &c.Attempts, &c.CreatedAt, &c.ExpiresAt, &c.UsedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan mfa challenge: %w", err)
+		}
+
+		challenges = append(challenges, c)
+	}
+
+	return challenges, rows.Err()
+}
+
+// AddAttempt увеличивает счётчик неверных кодов и возвращает новое значение.
+func (r *PostgresMFAChallengeRepository) AddAttempt(ctx context.Context, id string) (int, error) {
+	query := `UPDATE mfa_challenges SET attempts = attempts + 1 WHERE id = $1 RETURNING attempts`
+
+	var attempts int
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("add mfa attempt: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// Use помечает неиспользованные токены по фильтру использованными.
+// Возвращает количество помеченных.
+func (r *PostgresMFAChallengeRepository) Use(ctx context.Context, filter models.MFAChallengeFilter, usedAt time.Time) (int, error) {
+	qb := newQueryBuilder()
+	set := `UPDATE mfa_challenges SET used_at = ` + qb.addArg(usedAt)
+
+	qb.buildMFAChallengeFilter(filter)
+	qb.addRawCondition("used_at IS NULL")
+
+	result, err := r.db.ExecContext(ctx, set+qb.whereClause(), qb.args...)
+	if err != nil {
+		return 0, fmt.Errorf("use mfa challenges: %w", err)
+	}
+
+	count, _ := result.RowsAffected()
+
+	return int(count), nil
+}
+
+// buildMFAChallengeFilter применяет фильтр токенов второго шага входа к query builder.
+func (qb *queryBuilder) buildMFAChallengeFilter(filter models.MFAChallengeFilter) {
+	if len(filter.IDs) > 0 {
+		qb.addInCondition("id", toAnySlice(filter.IDs))
+	}
+
+	if len(filter.UserIDs) > 0 {
+		qb.addInCondition("user_id", toAnySlice(filter.UserIDs))
+	}
+
+	if len(filter.TokenHashes) > 0 {
+		qb.addInCondition("token_hash", toAnySlice(filter.TokenHashes))
+	}
+
+	if filter.ActiveAt != nil {
+		qb.addRawCondition("used_at IS NULL")
+		qb.addComparison("expires_at", ">", *filter.ActiveAt)
+	}
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// PostgresOrganizationRepository - PostgreSQL реализация репозитория организаций.
+// Все методы ограничены арендатором из контекста.
+type PostgresOrganizationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresOrganizationRepository создаёт новый PostgreSQL репозиторий организаций.
+func NewPostgresOrganizationRepository(db *sql.DB) *PostgresOrganizationRepository {
+	return &PostgresOrganizationRepository{db: db}
+}
+
+// Create сохраняет организацию в БД в арендаторе из контекста.
+func (r *PostgresOrganizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	tenantID, all, err := tenant.Scope(ctx)
+	if err != nil || all {
+		return types.ErrTenantRequired
+	}
+
+	org.TenantID = tenantID
+
+	query := `
+		INSERT INTO organizations (id, tenant_id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, org.ID, org.TenantID, org.Name, org.CreatedAt, org.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert organization: %w", err)
+	}
+
+	return nil
+}
+
+// Find возвращает организации по фильтру, упорядоченные по названию.
+func (r *PostgresOrganizationRepository) Find(
+	ctx context.Context,
+	filter models.OrganizationFilter,
+	pagination *models.Pagination,
+) ([]*models.Organization, error) {
+	qb := newQueryBuilder()
+	if err := qb.addTenantScope(ctx); err != nil {
+		return nil, err
+	}
+
+	qb.buildOrganizationFilter(filter)
+
+	query := `SELECT id, tenant_id, name, created_at, updated_at FROM organizations` +
+		qb.whereClause() +
+		` ORDER BY name, id` +
+		qb.addPagination(pagination)
+
+	rows, err := r.db.QueryContext(ctx, query, qb.args...)
+	if err != nil {
+		return nil, fmt.Errorf("query organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var orgs []*models.Organization
+
+	for rows.Next() {
+		org := &models.Organization{}
+		if err := rows.Scan(&org.ID, &org.TenantID, &org.Name, &org.CreatedAt, &org.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan organization: %w", err)
+		}
+
+		orgs = append(orgs, org)
+	}
+
+	return orgs, rows.Err()
+}
+
+// Count возвращает количество организаций по фильтру.
+func (r *PostgresOrganizationRepository) Count(ctx context.Context, filter models.OrganizationFilter) (int, error) {
+	qb := newQueryBuilder()
+	if err := qb.addTenantScope(ctx); err != nil {
+		return 0, err
+	}
+
+	qb.buildOrganizationFilter(filter)
+
+	var count int
+
+	query := `SELECT COUNT(*) FROM organizations` + qb.whereClause()
+	if err := r.db.QueryRowContext(ctx, query, qb.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count organizations: %w", err)
+	}
+
+	return count, nil
+}
+
+// Delete удаляет организации по фильтру вместе с членством в них.
+// Возвращает количество удалённых.
+func (r *PostgresOrganizationRepository) Delete(ctx context.Context, filter models.OrganizationFilter) (int, error) {
+	qb := newQueryBuilder()
+	if err := qb.addTenantScope(ctx); err != nil {
+		return 0, err
+	}
+
+	qb.buildOrganizationFilter(filter)
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM organizations`+qb.whereClause(), qb.args...)
+	if err != nil {
+		return 0, fmt.Errorf("delete organizations: %w", err)
+	}
+
+	count, _ := result.RowsAffected()
+
+	return int(count), nil
+}
+
+// buildOrganizationFilter применяет фильтр организаций к query builder.
+func (qb *queryBuilder) buildOrganizationFilter(filter models.OrganizationFilter) {
+	if len(filter.IDs) > 0 {
+		qb.addInCondition("id", toAnySlice(filter.IDs))
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+// PostgresPasswordResetRepository - PostgreSQL реализация репозитория токенов сброса пароля.
+type PostgresPasswordResetRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPasswordResetRepository создаёт новый PostgreSQL репозиторий токенов сброса пароля.
+func NewPostgresPasswordResetRepository(db *sql.DB) *PostgresPasswordResetRepository {
+	return &PostgresPasswordResetRepository{db: db}
+}
+
+// Create сохраняет токен сброса пароля в БД.
+func (r *PostgresPasswordResetRepository) Create(ctx context.Context, reset *models.PasswordReset) error {
+	query := `
+		INSERT INTO password_resets (id, user_id, token_hash, created_at, expires_at, used_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		reset.ID, reset.UserID, reset.TokenHash, reset.CreatedAt, reset.ExpiresAt, reset.UsedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("insert password reset: %w", err)
+	}
+
+	return nil
+}
+
+// Find возвращает токены сброса пароля по фильтру, новые первыми.
+func (r *PostgresPasswordResetRepository) Find(ctx context.Context, filter models.PasswordResetFilter) ([]*models.PasswordReset, error) {
+	qb := newQueryBuilder()
+	qb.buildPasswordResetFilter(filter)
+
+	query := `SELECT id, user_id, token_hash, created_at, expires_at, used_at FROM password_resets` +
+		qb.whereClause() +
+		` ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, qb.args...)
+	if err != nil {
+		return nil, fmt.Errorf("query password resets: %w", err)
+	}
+	defer rows.Close()
+
+	var resets []*models.PasswordReset
+
+	for rows.Next() {
+		reset := &models.PasswordReset{}
+		if err := rows.Scan(
+			&reset.ID, &reset.UserID, &reset.TokenHash, &reset.CreatedAt, &reset.ExpiresAt, &reset.UsedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan password reset: %w", err)
+		}
+
+		resets = append(resets, reset)
+	}
+
+	return resets, rows.Err()
+}
+
+// Count возвращает количество токенов сброса пароля по фильтру.
+func (r *PostgresPasswordResetRepository) Count(ctx context.Context, filter models.PasswordResetFilter) (int, error) {
+	qb := newQueryBuilder()
+	qb.buildPasswordResetFilter(filter)
+
+	var count int
+
+	query := `SELECT COUNT(*) FROM password_resets` + qb.whereClause()
+	if err := r.db.QueryRowContext(ctx, query, qb.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count password resets: %w", err)
+	}
+
+	return count, nil
+}
+
+// Use помечает неиспользованные токены по фильтру использованными.
+// Возвращает количество помеченных.
+func (r *PostgresPasswordResetRepository) Use(ctx context.Context, filter models.PasswordResetFilter, usedAt time.Time) (int, error) {
+	qb := newQueryBuilder()
+	set := `UPDATE password_resets SET used_at = ` + qb.addArg(usedAt)
+
+	qb.buildPasswordResetFilter(filter)
+	qb.addRawCondition("used_at IS NULL")
+
+	result, err := r.db.ExecContext(ctx, set+qb.whereClause(), qb.args...)
+	if err != nil {
+		return 0, fmt.Errorf("use password resets: %w", err)
+	}
+
+	count, _ := result.RowsAffected()
+
+	return int(count), nil
+}
+
+// buildPasswordResetFilter применяет фильтр токенов сброса пароля к query builder.
+func (qb *queryBuilder) buildPasswordResetFilter(filter models.PasswordResetFilter) {
+	if len(filter.IDs) > 0 {
+		qb.addInCondition("id", toAnySlice(filter.IDs))
+	}
+
+	if len(filter.UserIDs) > 0 {
+		qb.addInCondition("user_id", toAnySlice(filter.UserIDs))
+	}
+
+	if len(filter.TokenHashes) > 0 {
+		qb.addInCondition("token_hash", toAnySlice(filter.TokenHashes))
+	}
+
+	if filter.CreatedAfter != nil {
+		qb.addComparison("created_at", ">", *filter.CreatedAfter)
+	}
+
+	if filter.ActiveAt != nil {
+		qb.addRawCondition("used_at IS NULL")
+		qb.addComparison("expires_at", ">", *filter.ActiveAt)
+	}
+}
@@ -1,9 +1,16 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
 )
 
+// uniqueViolation - код ошибки PostgreSQL unique_violation.
+const uniqueViolation = "23505"
+
 // PostgresRepository - PostgreSQL реализация репозитория.
 type PostgresRepository struct {
 	db *sql.DB
@@ -13,3 +20,15 @@ type PostgresRepository struct {
 func NewPostgresRepository(db *sql.DB) *PostgresRepository {
 	return &PostgresRepository{db: db}
 }
+
+// Ping проверяет доступность БД.
+func (r *PostgresRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// isUniqueViolation проверяет, нарушено ли ограничение уникальности.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
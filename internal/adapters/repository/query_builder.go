@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
 )
 
 // queryBuilder помогает строить SQL-запросы с параметрами.
@@ -20,6 +21,15 @@ func newQueryBuilder() *queryBuilder {
 
 // addInCondition добавляет условие IN с множеством значений.
 func (qb *queryBuilder) addInCondition(column string, values []any) {
+	qb.addListCondition(column, "IN", values)
+}
+
+// addNotInCondition добавляет условие NOT IN с множеством значений.
+func (qb *queryBuilder) addNotInCondition(column string, values []any) {
+	qb.addListCondition(column, "NOT IN", values)
+}
+
+func (qb *queryBuilder) addListCondition(column, operator string, values []any) {
 	if len(values) == 0 {
 		return
 	}
@@ -27,12 +37,61 @@ func (qb *queryBuilder) addInCondition(column string, values []any) {
 	placeholders := make([]string, len(values))
 
 	for i, v := range values {
-		placeholders[i] = fmt.Sprintf("$%d", qb.argNum)
-		qb.args = append(qb.args, v)
-		qb.argNum++
+		placeholders[i] = qb.addArg(v)
+	}
+
+	qb.conditions = append(qb.conditions, fmt.Sprintf("%s %s (%s)", column, operator, strings.Join(placeholders, ", ")))
+}
+
+// addTimeRange добавляет условия полуинтервала [From, To) по колонке.
+func (qb *queryBuilder) addTimeRange(column string, r models.TimeRange) {
+	if r.From != nil {
+		qb.addComparison(column, ">=", *r.From)
+	}
+
+	if r.To != nil {
+		qb.addComparison(column, "<", *r.To)
+	}
+}
+
+// addStringMatch добавляет регистронезависимый поиск по началу строки или подстроке.
+func (qb *queryBuilder) addStringMatch(column string, m models.StringMatch) {
+	if !m.IsSet() {
+		return
+	}
+
+	pattern := "%" + escapeLike(m.Value) + "%"
+	if m.Prefix {
+		pattern = escapeLike(m.Value) + "%"
 	}
 
-	qb.conditions = append(qb.conditions, fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")))
+	qb.addComparison(column, "ILIKE", pattern)
+}
+
+// likeEscaper экранирует спецсимволы LIKE (экранирующий символ по умолчанию - \).
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// addArg регистрирует параметр запроса и возвращает его плейсхолдер.
+func (qb *queryBuilder) addArg(value any) string {
+	placeholder := fmt.Sprintf("$%d", qb.argNum)
+	qb.args = append(qb.args, value)
+	qb.argNum++
+
+	return placeholder
+}
+
+// addComparison добавляет условие сравнения колонки со значением.
+func (qb *queryBuilder) addComparison(column, operator string, value any) {
+	qb.conditions = append(qb.conditions, fmt.Sprintf("%s %s %s", column, operator, qb.addArg(value)))
+}
+
+// addRawCondition добавляет условие без параметров.
+func (qb *queryBuilder) addRawCondition(condition string) {
+	qb.conditions = append(qb.conditions, condition)
 }
 
 // whereClause возвращает WHERE часть запроса.
@@ -45,11 +104,17 @@ func (qb *queryBuilder) whereClause() string {
 }
 
 // addPagination добавляет LIMIT и OFFSET.
+// При keyset пагинации (задан After) OFFSET не добавляется:
+// условие на курсор добавляется вызывающим через addKeysetCondition.
 func (qb *queryBuilder) addPagination(pagination *models.Pagination) string {
 	if pagination == nil {
 		return ""
 	}
 
+	if pagination.After != nil {
+		return " LIMIT " + qb.addArg(pagination.Limit)
+	}
+
 	clause := fmt.Sprintf(" LIMIT $%d OFFSET $%d", qb.argNum, qb.argNum+1)
 
 	qb.args = append(qb.args, pagination.Limit, pagination.Offset)
@@ -58,6 +123,97 @@ func (qb *queryBuilder) addPagination(pagination *models.Pagination) string {
 	return clause
 }
 
+// sortKey - колонка сортировки с направлением.
+type sortKey struct {
+	field  models.SortField
+	column string
+	desc   bool
+}
+
+// sortByID - служебный ключ, замыкающий любую сортировку пользователей.
+const sortByID models.SortField = "id"
+
+// byteOrder - побайтовое сравнение строк независимо от collation базы.
+// Так же сравнивает models.CompareCursors, поэтому порядок страниц
+// и курсоры совпадают с in-memory репозиторием.
+const byteOrder = ` COLLATE "C"`
+
+// userSortColumns - допустимые колонки сортировки пользователей.
+// Имена колонок попадают в SQL как есть, поэтому берутся только отсюда.
+var userSortColumns = map[models.SortField]string{
+	models.SortByCreatedAt: "created_at",
+	models.SortByUpdatedAt: "updated_at",
+	models.SortByEmail:     "email" + byteOrder,
+	models.SortByName:      "name" + byteOrder,
+}
+
+// userSortKeys проверяет сортировку по allowlist и дополняет её ключом id
+// в направлении последнего ключа, чтобы порядок был однозначным.
+func userSortKeys(orders []models.SortOrder) ([]sortKey, error) {
+	if len(orders) == 0 {
+		orders = models.DefaultSort
+	}
+
+	keys := make([]sortKey, 0, len(orders)+1)
+
+	for _, order := range orders {
+		column, ok := userSortColumns[order.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", types.ErrInvalidOrderBy, order.Field)
+		}
+
+		keys = append(keys, sortKey{field: order.Field, column: column, desc: order.Desc})
+	}
+
+	keys = append(keys, sortKey{field: sortByID, column: "id" + byteOrder, desc: orders[len(orders)-1].Desc})
+
+	return keys, nil
+}
+
+// orderByClause возвращает ORDER BY часть запроса.
+func orderByClause(keys []sortKey) string {
+	parts := make([]string, len(keys))
+
+	for i, key := range keys {
+		parts[i] = key.column + " ASC"
+		if key.desc {
+			parts[i] = key.column + " DESC"
+		}
+	}
+
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// addKeysetCondition добавляет условие "строка после курсора" для сортировки keys:
+// (k1 > $1) OR (k1 = $1 AND k2 > $2) OR ..., с < для ключей по убыванию.
+func (qb *queryBuilder) addKeysetCondition(keys []sortKey, values []any) {
+	placeholders := make([]string, len(values))
+
+	for i, v := range values {
+		placeholders[i] = qb.addArg(v)
+	}
+
+	alternatives := make([]string, len(keys))
+
+	for i, key := range keys {
+		var parts []string
+
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s = %s", keys[j].column, placeholders[j]))
+		}
+
+		operator := ">"
+		if key.desc {
+			operator = "<"
+		}
+
+		parts = append(parts, fmt.Sprintf("%s %s %s", key.column, operator, placeholders[i]))
+		alternatives[i] = "(" + strings.Join(parts, " AND ") + ")"
+	}
+
+	qb.conditions = append(qb.conditions, "("+strings.Join(alternatives, " OR ")+")")
+}
+
 func toAnySlice[T any](s []T) []any {
 	result := make([]any, len(s))
 
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// PostgresRoleRepository - PostgreSQL реализация хранилища ролей пользователей.
+type PostgresRoleRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRoleRepository создаёт новое PostgreSQL хранилище ролей пользователей.
+func NewPostgresRoleRepository(db *sql.DB) *PostgresRoleRepository {
+	return &PostgresRoleRepository{db: db}
+}
+
+// List возвращает роли пользователя в алфавитном порядке.
+func (r *PostgresRoleRepository) List(ctx context.Context, userID string) ([]types.Role, error) {
+	query := `SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []types.Role
+
+	for rows.Next() {
+		var role types.Role
+		if err := rows.Scan(&role); err != nil {
+			return nil, fmt.Errorf("scan role: %w", err)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+// Add выдаёт роль. Повторная выдача той же роли не считается ошибкой.
+func (r *PostgresRoleRepository) Add(ctx context.Context, userID string, role types.Role) error {
+	query := `INSERT INTO user_roles (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, role); err != nil {
+		return fmt.Errorf("insert role: %w", err)
+	}
+
+	return nil
+}
+
+// Remove отзывает роль пользователя.
+func (r *PostgresRoleRepository) Remove(ctx context.Context, userID string, role types.Role) error {
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND role = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, role); err != nil {
+		return fmt.Errorf("delete role: %w", err)
+	}
+
+	return nil
+}
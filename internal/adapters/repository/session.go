@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// PostgresSessionRepository - PostgreSQL реализация репозитория сессий.
+type PostgresSessionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSessionRepository создаёт новый PostgreSQL репозиторий сессий.
+func NewPostgresSessionRepository(db *sql.DB) *PostgresSessionRepository {
+	return &PostgresSessionRepository{db: db}
+}
+
+// Create сохраняет сессию в БД.
+func (r *PostgresSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	query := `
+		INSERT INTO sessions (id, user_id, refresh_token_hash, user_agent, ip,
+			created_at, last_used_at, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		session.ID, session.UserID, session.RefreshTokenHash, session.UserAgent, session.IP,
+		session.CreatedAt, session.LastUsedAt, session.ExpiresAt, session.RevokedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("insert session: %w", err)
+	}
+
+	return nil
+}
+
+// Find возвращает сессии по фильтру, новые первыми.
+func (r *PostgresSessionRepository) Find(ctx context.Context, filter models.SessionFilter) ([]*models.Session, error) {
+	qb := newQueryBuilder()
+	qb.buildSessionFilter(filter)
+
+	query := `SELECT id, user_id, refresh_token_hash, user_agent, ip,
+		created_at, last_used_at, expires_at, revoked_at FROM sessions` +
+		qb.whereClause() +
+		` ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, qb.args...)
+	if err != nil {
+		return nil, fmt.Errorf("query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*models.Session
+
+	for rows.Next() {
+		session := &models.Session{}
+		if err := rows.Scan(
+			&session.ID, &session.UserID, &session.RefreshTokenHash, &session.UserAgent, &session.IP,
+			&session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt, &session.RevokedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan session: %w", err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+// Rotate сохраняет сессию с новым refresh токеном. Условие на старый
+// хэш делает обмен атомарным: из параллельных запросов с одним токеном
+// строку обновит только один.
+func (r *PostgresSessionRepository) Rotate(ctx context.Context, session *models.Session, previousHash string) error {
+	query := `
+		UPDATE sessions SET refresh_token_hash = $2, user_agent = $3, ip = $4,
+			last_used_at = $5, expires_at = $6
+		WHERE id = $1 AND refresh_token_hash = $7 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		session.ID, session.RefreshTokenHash, session.UserAgent, session.IP,
+		session.LastUsedAt, session.ExpiresAt, previousHash,
+	)
+
+	if err != nil {
+		return fmt.Errorf("rotate session: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rotate session: %w", err)
+	}
+
+	if rows == 0 {
+		return types.ErrInvalidToken
+	}
+
+	return nil
+}
+
+// Revoke отзывает сессии по фильтру. Возвращает количество отозванных.
+func (r *PostgresSessionRepository) Revoke(ctx context.Context, filter models.SessionFilter, revokedAt time.Time) (int, error) {
+	qb := newQueryBuilder()
+	set := `UPDATE sessions SET revoked_at = ` + qb.addArg(revokedAt)
+
+	qb.buildSessionFilter(filter)
+	qb.addRawCondition("revoked_at IS NULL")
+
+	query := set + qb.whereClause()
+
+	result, err := r.db.ExecContext(ctx, query, qb.args...)
+	if err != nil {
+		return 0, fmt.Errorf("revoke sessions: %w", err)
+	}
+
+	count, _ := result.RowsAffected()
+
+	return int(count), nil
+}
+
+// buildSessionFilter применяет фильтр сессий к query builder.
+func (qb *queryBuilder) buildSessionFilter(filter models.SessionFilter) {
+	if len(filter.IDs) > 0 {
+		qb.addInCondition("id", toAnySlice(filter.IDs))
+	}
+
+	if len(filter.UserIDs) > 0 {
+		qb.addInCondition("user_id", toAnySlice(filter.UserIDs))
+	}
+
+	if len(filter.RefreshTokenHashes) > 0 {
+		qb.addInCondition("refresh_token_hash", toAnySlice(filter.RefreshTokenHashes))
+	}
+
+	if filter.ActiveAt != nil {
+		qb.addRawCondition("revoked_at IS NULL")
+		qb.addComparison("expires_at", ">", *filter.ActiveAt)
+	}
+}
@@ -2,24 +2,51 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
 	"github.com/obsessed-gopher/micro-service-guide/internal/types"
 )
 
-// Create сохраняет пользователя в БД.
+// userColumns - колонки пользователя в порядке, ожидаемом scanUsers.
+const userColumns = `id, tenant_id, email, email_normalized, email_verified_at, name, password_hash,
+	password_changed_at, totp_secret, totp_enabled_at, totp_last_step, recovery_code_hashes,
+	status, version, created_at, updated_at, deleted_at`
+
+// Create сохраняет пользователя в БД в арендаторе из контекста.
 func (r *PostgresRepository) Create(ctx context.Context, user *models.User) error {
+	tenantID, all, err := tenant.Scope(ctx)
+	if err != nil || all {
+		return types.ErrTenantRequired
+	}
+
+	user.TenantID = tenantID
+
 	query := `
-		INSERT INTO users (id, email, name, password_hash, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO users (id, tenant_id, email, email_normalized, email_verified_at, name, password_hash,
+			password_changed_at, totp_secret, totp_enabled_at, totp_last_step, recovery_code_hashes,
+			status, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
-		user.ID, user.Email, user.Name, user.PasswordHash,
-		user.Status, user.CreatedAt, user.UpdatedAt,
+	_, err = r.db.ExecContext(ctx, query,
+		user.ID, user.TenantID, user.Email, user.EmailNormalized, user.EmailVerifiedAt, user.Name, user.PasswordHash,
+		user.PasswordChangedAt, user.TOTPSecret, user.TOTPEnabledAt, user.TOTPLastStep, pq.Array(user.RecoveryCodeHashes),
+		user.Status, user.Version, user.CreatedAt, user.UpdatedAt,
 	)
 
+	if isUniqueViolation(err) {
+		return types.ErrUserAlreadyExists
+	}
+
 	if err != nil {
 		return fmt.Errorf("insert user: %w", err)
 	}
@@ -29,27 +56,53 @@ func (r *PostgresRepository) Create(ctx context.Context, user *models.User) erro
 
 // Find возвращает пользователей по фильтру.
 func (r *PostgresRepository) Find(ctx context.Context, filter models.UserFilter, pagination *models.Pagination) ([]*models.User, error) {
+	var orders []models.SortOrder
+	if pagination != nil {
+		orders = pagination.Sort
+	}
+
+	keys, err := userSortKeys(orders)
+	if err != nil {
+		return nil, err
+	}
+
 	qb := newQueryBuilder()
+	if err := qb.addTenantScope(ctx); err != nil {
+		return nil, err
+	}
+
 	qb.buildUserFilter(filter)
 
-	query := `SELECT id, email, name, password_hash, status, created_at, updated_at FROM users` +
+	if pagination != nil && pagination.After != nil {
+		qb.addKeysetCondition(keys, cursorValues(pagination.After, keys))
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users` +
 		qb.whereClause() +
-		` ORDER BY created_at DESC` +
+		orderByClause(keys) +
 		qb.addPagination(pagination)
 
+	slog.DebugContext(ctx, "query users", slog.String("query", query))
+
 	rows, err := r.db.QueryContext(ctx, query, qb.args...)
 	if err != nil {
 		return nil, fmt.Errorf("query users: %w", err)
 	}
 	defer rows.Close()
 
+	return scanUsers(rows)
+}
+
+// scanUsers читает пользователей из результата SELECT с полным набором колонок.
+func scanUsers(rows *sql.Rows) ([]*models.User, error) {
 	var users []*models.User
 
 	for rows.Next() {
 		user := &models.User{}
 		if err := rows.Scan(
-			&user.ID, &user.Email, &user.Name, &user.PasswordHash,
-			&user.Status, &user.CreatedAt, &user.UpdatedAt,
+			&user.ID, &user.TenantID, &user.Email, &user.EmailNormalized, &user.EmailVerifiedAt, &user.Name, &user.PasswordHash,
+			&user.PasswordChangedAt, &user.TOTPSecret, &user.TOTPEnabledAt, &user.TOTPLastStep, pq.Array(&user.RecoveryCodeHashes),
+			&user.Status, &user.Version, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan user: %w", err)
 		}
@@ -60,13 +113,79 @@ func (r *PostgresRepository) Find(ctx context.Context, filter models.UserFilter,
 	return users, rows.Err()
 }
 
+// Search ищет пользователей по имени и email через search_vector, самые
+// релевантные первыми. Возвращает страницу результатов и общее количество найденных.
+func (r *PostgresRepository) Search(ctx context.Context, query string, filter models.UserFilter, pagination *models.Pagination) ([]*models.User, int, error) {
+	tsQuery := prefixTSQuery(models.SearchTerms(query))
+	if tsQuery == "" {
+		return nil, 0, nil
+	}
+
+	qb := newQueryBuilder()
+	match := `to_tsquery('simple', ` + qb.addArg(tsQuery) + `)`
+
+	qb.addRawCondition("search_vector @@ " + match)
+
+	if err := qb.addTenantScope(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	qb.buildUserFilter(filter)
+
+	var total int
+
+	countQuery := `SELECT COUNT(*) FROM users` + qb.whereClause()
+	if err := r.db.QueryRowContext(ctx, countQuery, qb.args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count search results: %w", err)
+	}
+
+	selectQuery := `SELECT ` + userColumns + ` FROM users` +
+		qb.whereClause() +
+		` ORDER BY ts_rank(search_vector, ` + match + `) DESC, created_at DESC, id` + byteOrder + ` DESC` +
+		qb.addPagination(pagination)
+
+	slog.DebugContext(ctx, "search users", slog.String("query", selectQuery))
+
+	rows, err := r.db.QueryContext(ctx, selectQuery, qb.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search users: %w", err)
+	}
+	defer rows.Close()
+
+	users, err := scanUsers(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// prefixTSQuery строит tsquery, в котором каждый терм ищется по префиксу:
+// "ali acm" -> 'ali':* & 'acm':*. Термы состоят только из букв и цифр,
+// поэтому экранирование не требуется.
+func prefixTSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+
+	for i, term := range terms {
+		parts[i] = "'" + term + "':*"
+	}
+
+	return strings.Join(parts, " & ")
+}
+
 // Count возвращает количество пользователей по фильтру.
 func (r *PostgresRepository) Count(ctx context.Context, filter models.UserFilter) (int, error) {
 	qb := newQueryBuilder()
+	if err := qb.addTenantScope(ctx); err != nil {
+		return 0, err
+	}
+
 	qb.buildUserFilter(filter)
 
 	query := `SELECT COUNT(*) FROM users` + qb.whereClause()
 
+	slog.DebugContext(ctx, "count users", slog.String("query", query))
+
 	var count int
 
 	if err := r.db.QueryRowContext(ctx, query, qb.args...).Scan(&count); err != nil {
@@ -76,46 +195,166 @@ func (r *PostgresRepository) Count(ctx context.Context, filter models.UserFilter
 	return count, nil
 }
 
-// Update обновляет пользователя в БД.
+// Update обновляет пользователя в БД, если его версия совпадает с сохранённой.
+// При успехе версия пользователя увеличивается. Арендатор не меняется.
 func (r *PostgresRepository) Update(ctx context.Context, user *models.User) error {
+	scope, err := tenantArg(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		UPDATE users SET email = $2, name = $3, status = $4, updated_at = $5
-		WHERE id = $1
+		UPDATE users SET email = $2, email_normalized = $3, email_verified_at = $4, name = $5,
+			password_hash = $6, password_changed_at = $7, totp_secret = $8, totp_enabled_at = $9,
+			totp_last_step = $10, recovery_code_hashes = $11, status = $12, updated_at = $13, deleted_at = $14,
+			version = version + 1
+		WHERE id = $1 AND version = $15 AND ($16::varchar IS NULL OR tenant_id = $16)
+		RETURNING tenant_id
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
-		user.ID, user.Email, user.Name, user.Status, user.UpdatedAt,
-	)
+	err = r.db.QueryRowContext(ctx, query,
+		user.ID, user.Email, user.EmailNormalized, user.EmailVerifiedAt, user.Name, user.PasswordHash,
+		user.PasswordChangedAt, user.TOTPSecret, user.TOTPEnabledAt, user.TOTPLastStep, pq.Array(user.RecoveryCodeHashes),
+		user.Status, user.UpdatedAt, user.DeletedAt, user.Version, scope,
+	).Scan(&user.TenantID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.updateMissError(ctx, user.ID, scope)
+	}
+
+	if isUniqueViolation(err) {
+		return types.ErrUserAlreadyExists
+	}
 
 	if err != nil {
 		return fmt.Errorf("update user: %w", err)
 	}
 
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
+	user.Version++
+
+	return nil
+}
+
+// updateMissError определяет, почему UPDATE не затронул строк:
+// пользователя нет или его версия уже изменилась.
+func (r *PostgresRepository) updateMissError(ctx context.Context, id string, scope any) error {
+	var exists bool
+
+	query := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND ($2::varchar IS NULL OR tenant_id = $2))`
+
+	err := r.db.QueryRowContext(ctx, query, id, scope).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("check user exists: %w", err)
+	}
+
+	if !exists {
 		return types.ErrUserNotFound
 	}
 
-	return nil
+	return types.ErrVersionConflict
 }
 
-// Delete удаляет пользователей по фильтру. Возвращает количество удалённых.
-func (r *PostgresRepository) Delete(ctx context.Context, filter models.UserFilter) (int, error) {
+// Delete мягко удаляет пользователей по фильтру и исключает их из организаций.
+// Возвращает количество удалённых. Уже удалённые пользователи не затрагиваются.
+func (r *PostgresRepository) Delete(ctx context.Context, filter models.UserFilter, deletedAt time.Time) (int, error) {
 	qb := newQueryBuilder()
+	placeholder := qb.addArg(deletedAt)
+	set := `UPDATE users SET deleted_at = ` + placeholder + `, updated_at = ` + placeholder + `, version = version + 1`
+
+	if err := qb.addTenantScope(ctx); err != nil {
+		return 0, err
+	}
+
+	filter.IncludeDeleted = false
 	qb.buildUserFilter(filter)
 
-	query := `DELETE FROM users` + qb.whereClause()
+	// Членство удаляется тем же запросом, поэтому пользователь не может
+	// остаться удалённым, но числиться в организации.
+	query := `WITH deleted AS (` + set + qb.whereClause() + ` RETURNING id),
+		removed AS (DELETE FROM organization_members WHERE user_id IN (SELECT id FROM deleted))
+		SELECT count(*) FROM deleted`
 
-	result, err := r.db.ExecContext(ctx, query, qb.args...)
-	if err != nil {
+	slog.DebugContext(ctx, "delete users", slog.String("query", query))
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, qb.args...).Scan(&count); err != nil {
 		return 0, fmt.Errorf("delete users: %w", err)
 	}
 
+	return count, nil
+}
+
+// Purge физически удаляет пользователей, удалённых раньше deletedBefore.
+// Сессии удаляются каскадно.
+func (r *PostgresRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	scope, err := tenantArg(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `DELETE FROM users WHERE deleted_at < $1 AND ($2::varchar IS NULL OR tenant_id = $2)`
+
+	result, err := r.db.ExecContext(ctx, query, deletedBefore, scope)
+	if err != nil {
+		return 0, fmt.Errorf("purge users: %w", err)
+	}
+
 	count, _ := result.RowsAffected()
 
 	return int(count), nil
 }
 
+// tenantArg возвращает арендатора из контекста как аргумент запроса:
+// nil для системных операций над всеми арендаторами.
+func tenantArg(ctx context.Context) (any, error) {
+	tenantID, all, err := tenant.Scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if all {
+		return nil, nil
+	}
+
+	return tenantID, nil
+}
+
+// addTenantScope ограничивает запрос арендатором из контекста.
+func (qb *queryBuilder) addTenantScope(ctx context.Context) error {
+	scope, err := tenantArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	if scope != nil {
+		qb.addComparison("tenant_id", "=", scope)
+	}
+
+	return nil
+}
+
+// cursorValues возвращает значения курсора для ключей сортировки.
+func cursorValues(cursor *models.Cursor, keys []sortKey) []any {
+	values := make([]any, len(keys))
+
+	for i, key := range keys {
+		switch key.field {
+		case models.SortByCreatedAt:
+			values[i] = cursor.CreatedAt
+		case models.SortByUpdatedAt:
+			values[i] = cursor.UpdatedAt
+		case models.SortByEmail:
+			values[i] = cursor.Email
+		case models.SortByName:
+			values[i] = cursor.Name
+		case sortByID:
+			values[i] = cursor.ID
+		}
+	}
+
+	return values
+}
+
 // buildUserFilter применяет фильтр пользователей к query builder.
 func (qb *queryBuilder) buildUserFilter(filter models.UserFilter) {
 	if len(filter.IDs) > 0 {
@@ -123,12 +362,34 @@ func (qb *queryBuilder) buildUserFilter(filter models.UserFilter) {
 	}
 
 	if len(filter.Emails) > 0 {
-		qb.addInCondition("email", toAnySlice(filter.Emails))
+		qb.addInCondition("email_normalized", toAnySlice(filter.Emails))
 	}
 
 	if len(filter.Statuses) > 0 {
 		qb.addInCondition("status", statusesToAny(filter.Statuses))
 	}
+
+	if len(filter.ExcludeStatuses) > 0 {
+		qb.addNotInCondition("status", statusesToAny(filter.ExcludeStatuses))
+	}
+
+	if len(filter.EmailDomains) > 0 {
+		domains := make([]any, len(filter.EmailDomains))
+		for i, d := range filter.EmailDomains {
+			domains[i] = strings.ToLower(d)
+		}
+
+		qb.addInCondition("lower(split_part(email, '@', 2))", domains)
+	}
+
+	qb.addStringMatch("email", filter.EmailMatch)
+	qb.addStringMatch("name", filter.NameMatch)
+	qb.addTimeRange("created_at", filter.CreatedAt)
+	qb.addTimeRange("updated_at", filter.UpdatedAt)
+
+	if !filter.IncludeDeleted {
+		qb.addRawCondition("deleted_at IS NULL")
+	}
 }
 
 func statusesToAny(statuses []types.UserStatus) []any {
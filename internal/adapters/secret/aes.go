@@ -0,0 +1,76 @@
+// Package secret содержит шифрование секретов перед сохранением в хранилище.
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// keySize - размер ключа AES-256 в байтах.
+const keySize = 32
+
+// AESCipher шифрует секреты AES-256-GCM. Результат - base64 от nonce и шифротекста.
+type AESCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESCipher создаёт шифр по ключу в base64 (32 байта после декодирования).
+func NewAESCipher(key string) (*AESCipher, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("decode key: %w", err)
+	}
+
+	if len(raw) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(raw))
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create gcm: %w", err)
+	}
+
+	return &AESCipher{aead: aead}, nil
+}
+
+// Encrypt шифрует строку.
+func (c *AESCipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt расшифровывает строку, зашифрованную Encrypt.
+func (c *AESCipher) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decode ciphertext: %w", err)
+	}
+
+	if len(sealed) < c.aead.NonceSize() {
+		return "", errors.New("ciphertext is too short")
+	}
+
+	nonce, data := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+
+	plaintext, err := c.aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt: %w", err)
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,57 @@
+package secret
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+var testKey = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", keySize)))
+
+func TestAESCipher_RoundTrip(t *testing.T) {
+	c, err := NewAESCipher(testKey)
+	if err != nil {
+		t.Fatalf("NewAESCipher() error = %v", err)
+	}
+
+	encrypted, err := c.Encrypt("JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	if strings.Contains(encrypted, "JBSWY3DPEHPK3PXP") {
+		t.Fatal("Encrypt() returned plaintext")
+	}
+
+	again, _ := c.Encrypt("JBSWY3DPEHPK3PXP")
+	if again == encrypted {
+		t.Error("Encrypt() is deterministic, nonce is not random")
+	}
+
+	decrypted, err := c.Decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+
+	if decrypted != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("Decrypt() = %q, want %q", decrypted, "JBSWY3DPEHPK3PXP")
+	}
+}
+
+func TestAESCipher_Errors(t *testing.T) {
+	if _, err := NewAESCipher(base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Error("NewAESCipher() accepted short key")
+	}
+
+	c, _ := NewAESCipher(testKey)
+	other, _ := NewAESCipher(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", keySize))))
+
+	encrypted, _ := other.Encrypt("secret")
+	if _, err := c.Decrypt(encrypted); err == nil {
+		t.Error("Decrypt() accepted ciphertext of another key")
+	}
+
+	if _, err := c.Decrypt("AAAA"); err == nil {
+		t.Error("Decrypt() accepted truncated ciphertext")
+	}
+}
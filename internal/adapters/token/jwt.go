@@ -0,0 +1,140 @@
+// Package token содержит реализации выпуска и проверки токенов.
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// jwtHeader - заголовок JWT, одинаковый для всех токенов (HS256).
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// jwtPayload - набор claims, который кладётся в токен.
+type jwtPayload struct {
+	Subject   string `json:"sub"`
+	TenantID  string `json:"tid,omitempty"`
+	SessionID string `json:"sid,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// refreshTokenSize - размер refresh токена в байтах до кодирования.
+const refreshTokenSize = 32
+
+// JWTManager - выпуск и проверка JWT, подписанных HMAC-SHA256,
+// а также выпуск непрозрачных refresh токенов.
+type JWTManager struct {
+	key        []byte
+	ttl        time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+// NewJWTManager создаёт новый JWT менеджер.
+func NewJWTManager(signingKey string, ttl, refreshTTL time.Duration) *JWTManager {
+	return &JWTManager{
+		key:        []byte(signingKey),
+		ttl:        ttl,
+		refreshTTL: refreshTTL,
+		now:        time.Now,
+	}
+}
+
+// IssueAccessToken выпускает подписанный access токен.
+func (m *JWTManager) IssueAccessToken(claims models.TokenClaims) (models.AccessToken, error) {
+	now := m.now()
+
+	payload, err := json.Marshal(jwtPayload{
+		Subject:   claims.UserID,
+		TenantID:  claims.TenantID,
+		SessionID: claims.SessionID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(m.ttl).Unix(),
+	})
+	if err != nil {
+		return models.AccessToken{}, fmt.Errorf("marshal claims: %w", err)
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return models.AccessToken{
+		Token:     unsigned + "." + m.sign(unsigned),
+		ExpiresAt: time.Unix(now.Add(m.ttl).Unix(), 0),
+	}, nil
+}
+
+// ParseAccessToken проверяет подпись и срок действия токена.
+func (m *JWTManager) ParseAccessToken(token string) (*models.TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return nil, types.ErrInvalidToken
+	}
+
+	expected := m.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, types.ErrInvalidToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, types.ErrInvalidToken
+	}
+
+	var payload jwtPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, types.ErrInvalidToken
+	}
+
+	expiresAt := time.Unix(payload.ExpiresAt, 0)
+	if !m.now().Before(expiresAt) {
+		return nil, types.ErrInvalidToken
+	}
+
+	return &models.TokenClaims{
+		UserID:    payload.Subject,
+		TenantID:  payload.TenantID,
+		SessionID: payload.SessionID,
+		IssuedAt:  time.Unix(payload.IssuedAt, 0),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// IssueRefreshToken выпускает случайный refresh токен.
+func (m *JWTManager) IssueRefreshToken() (models.RefreshToken, error) {
+	raw := make([]byte, refreshTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return models.RefreshToken{}, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	return models.RefreshToken{
+		Token:     token,
+		Hash:      m.HashRefreshToken(token),
+		ExpiresAt: m.now().Add(m.refreshTTL),
+	}, nil
+}
+
+// HashRefreshToken возвращает хэш refresh токена для хранения и поиска.
+func (m *JWTManager) HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// sign возвращает подпись HMAC-SHA256 в base64url.
+func (m *JWTManager) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, m.key)
+	mac.Write([]byte(unsigned))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
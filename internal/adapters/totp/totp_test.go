@@ -0,0 +1,77 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret - секрет тестовых векторов RFC 6238 ("12345678901234567890") в base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerator_Validate(t *testing.T) {
+	g := NewGenerator("acme")
+
+	// Последние 6 цифр кодов SHA1 из приложения B RFC 6238.
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	for _, tt := range tests {
+		step, ok := g.Validate(rfcSecret, tt.code, time.Unix(tt.unix, 0))
+		if !ok {
+			t.Errorf("Validate(%s) at %d: code rejected", tt.code, tt.unix)
+			continue
+		}
+
+		if want := tt.unix / 30; step != want {
+			t.Errorf("Validate(%s) at %d: step = %d, want %d", tt.code, tt.unix, step, want)
+		}
+	}
+}
+
+func TestGenerator_ValidateSkew(t *testing.T) {
+	g := NewGenerator("acme")
+	at := time.Unix(59, 0) // шаг 1, код 287082
+
+	if _, ok := g.Validate(rfcSecret, "287082", at.Add(30*time.Second)); !ok {
+		t.Error("code of previous step rejected")
+	}
+
+	if _, ok := g.Validate(rfcSecret, "287082", at.Add(90*time.Second)); ok {
+		t.Error("code older than skew accepted")
+	}
+
+	for _, code := range []string{"", "28708", "2870820", "000000"} {
+		if _, ok := g.Validate(rfcSecret, code, at); ok {
+			t.Errorf("Validate(%q) accepted", code)
+		}
+	}
+}
+
+func TestGenerator_SecretAndURI(t *testing.T) {
+	g := NewGenerator("Acme Corp")
+
+	secret, err := g.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	if len(secret) != 32 {
+		t.Errorf("secret length = %d, want 32", len(secret))
+	}
+
+	uri := g.URI(secret, "alice@acme.com")
+	for _, part := range []string{"otpauth://totp/Acme%20Corp:alice@acme.com?", "secret=" + secret, "issuer=Acme+Corp"} {
+		if !strings.Contains(uri, part) {
+			t.Errorf("URI() = %q, want it to contain %q", uri, part)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package auth содержит аутентификацию вызывающего по access токену
+// и проверку его прав, общие для gRPC и HTTP серверов.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// bearerPrefix - схема заголовка authorization с access токеном.
+const bearerPrefix = "Bearer "
+
+// TokenParser - интерфейс проверки access токенов.
+type TokenParser interface {
+	ParseAccessToken(token string) (*models.TokenClaims, error)
+}
+
+// RoleProvider - интерфейс получения ролей пользователя. Для удалённого
+// пользователя возвращает types.ErrUserNotFound, для заблокированного
+// и неактивного - types.ErrUserBlocked и types.ErrUserInactive.
+type RoleProvider interface {
+	UserRoles(ctx context.Context, userID string) ([]types.Role, error)
+}
+
+// Caller - аутентифицированный вызывающий.
+type Caller struct {
+	UserID    string
+	TenantID  string
+	SessionID string
+	Roles     []types.Role
+}
+
+// Can проверяет, что у вызывающего есть право на любых пользователей.
+func (c *Caller) Can(permission types.Permission) bool {
+	for _, role := range c.Roles {
+		if slices.Contains(role.Permissions(), permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanSelf проверяет, что у вызывающего есть право на собственную запись.
+func (c *Caller) CanSelf(permission types.Permission) bool {
+	for _, role := range c.Roles {
+		if slices.Contains(role.SelfPermissions(), permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rule - правило доступа к методу.
+// Public - метод доступен без токена. Permission - право, нужное для
+// вызова; пустое значение пускает любого аутентифицированного.
+// Self сообщает, что запрос касается собственной записи вызывающего,
+// и тогда достаточно права на свою запись. PermissionFor, если задана,
+// заменяет Permission правом, зависящим от содержимого запроса.
+// Extra возвращает права, которых дополнительно требует содержимое
+// запроса, например смена статуса.
+type Rule struct {
+	Public        bool
+	Permission    types.Permission
+	PermissionFor func(req any) types.Permission
+	Self          func(caller *Caller, req any) bool
+	Extra         func(req any) []types.Permission
+}
+
+// Authorizer аутентифицирует вызывающего и проверяет правила доступа.
+type Authorizer struct {
+	tokens TokenParser
+	roles  RoleProvider
+}
+
+// NewAuthorizer создаёт новую проверку доступа.
+func NewAuthorizer(tokens TokenParser, roles RoleProvider) *Authorizer {
+	return &Authorizer{
+		tokens: tokens,
+		roles:  roles,
+	}
+}
+
+// Authorize проверяет доступ к методу по значению заголовка authorization.
+// Для публичных методов возвращает nil без проверки токена. Ошибки:
+// types.ErrUnauthenticated без токена, types.ErrInvalidToken для
+// недействительного токена, types.ErrPermissionDenied при нехватке прав.
+func (a *Authorizer) Authorize(ctx context.Context, authorization string, rule Rule, req any) (*Caller, error) {
+	if rule.Public {
+		return nil, nil
+	}
+
+	caller, err := a.authenticate(ctx, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	permission := rule.Permission
+	if rule.PermissionFor != nil {
+		permission = rule.PermissionFor(req)
+	}
+
+	if permission != "" && !caller.Can(permission) {
+		self := rule.Self != nil && rule.Self(caller, req)
+		if !self || !caller.CanSelf(permission) {
+			return nil, types.ErrPermissionDenied
+		}
+	}
+
+	if rule.Extra != nil {
+		for _, permission := range rule.Extra(req) {
+			if !caller.Can(permission) {
+				return nil, types.ErrPermissionDenied
+			}
+		}
+	}
+
+	return caller, nil
+}
+
+// authenticate проверяет токен и загружает роли вызывающего.
+// Токен, выпущенный для другого арендатора или удалённого пользователя,
+// считается недействительным. Заблокированному и неактивному
+// пользователю доступ запрещён до истечения токена.
+func (a *Authorizer) authenticate(ctx context.Context, authorization string) (*Caller, error) {
+	if authorization == "" {
+		return nil, types.ErrUnauthenticated
+	}
+
+	token, ok := strings.CutPrefix(authorization, bearerPrefix)
+	if !ok || token == "" {
+		return nil, types.ErrInvalidToken
+	}
+
+	claims, err := a.tokens.ParseAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenantID, ok := tenant.FromContext(ctx); ok && claims.TenantID != tenantID {
+		return nil, types.ErrInvalidToken
+	}
+
+	roles, err := a.roles.UserRoles(ctx, claims.UserID)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, types.ErrUserNotFound):
+		return nil, types.ErrInvalidToken
+	case errors.Is(err, types.ErrUserBlocked), errors.Is(err, types.ErrUserInactive):
+		return nil, fmt.Errorf("%w: %v", types.ErrPermissionDenied, err)
+	default:
+		return nil, fmt.Errorf("get caller roles: %w", err)
+	}
+
+	return &Caller{
+		UserID:    claims.UserID,
+		TenantID:  claims.TenantID,
+		SessionID: claims.SessionID,
+		Roles:     roles,
+	}, nil
+}
+
+// callerKey - ключ вызывающего в контексте.
+type callerKey struct{}
+
+// WithCaller кладёт вызывающего в контекст.
+func WithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext возвращает вызывающего из контекста, nil для публичных методов.
+func CallerFromContext(ctx context.Context) *Caller {
+	caller, _ := ctx.Value(callerKey{}).(*Caller)
+	return caller
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// mockTokens принимает токен вида "<user_id>" и выдаёт его как subject
+// арендатора "acme".
+type mockTokens struct{}
+
+func (mockTokens) ParseAccessToken(token string) (*models.TokenClaims, error) {
+	if token == "expired" {
+		return nil, types.ErrInvalidToken
+	}
+	return &models.TokenClaims{UserID: token, TenantID: "acme", SessionID: "session-" + token}, nil
+}
+
+type mockRoles map[string][]types.Role
+
+// UserRoles отвечает для пользователей "blocked" и "deleted" так же,
+// как usecases.UserUsecase для заблокированного и удалённого.
+func (m mockRoles) UserRoles(ctx context.Context, userID string) ([]types.Role, error) {
+	switch userID {
+	case "blocked":
+		return nil, types.ErrUserBlocked
+	case "deleted":
+		return nil, types.ErrUserNotFound
+	}
+	return m[userID], nil
+}
+
+func TestAuthorizer_Authorize(t *testing.T) {
+	authorizer := NewAuthorizer(mockTokens{}, mockRoles{
+		"admin":   {types.RoleAdmin},
+		"support": {types.RoleSupport},
+		"self":    {types.RoleSelfService},
+	})
+
+	// Запросом в тесте выступает ID целевого пользователя.
+	self := func(caller *Caller, req any) bool { return req == caller.UserID }
+	statusChange := func(req any) []types.Permission { return []types.Permission{types.PermissionUsersBlock} }
+
+	read := Rule{Permission: types.PermissionUsersRead, Self: self}
+	write := Rule{Permission: types.PermissionUsersWrite, Self: self}
+	block := Rule{Permission: types.PermissionUsersWrite, Self: self, Extra: statusChange}
+	blockOnly := Rule{PermissionFor: func(any) types.Permission { return "" }, Self: self, Extra: statusChange}
+
+	tests := []struct {
+		name          string
+		authorization string
+		rule          Rule
+		req           any
+		wantErr       error
+	}{
+		{"public without token", "", Rule{Public: true}, nil, nil},
+		{"missing token", "", read, "self", types.ErrUnauthenticated},
+		{"wrong scheme", "Basic self", read, "self", types.ErrInvalidToken},
+		{"invalid token", "Bearer expired", read, "self", types.ErrInvalidToken},
+		{"self reads own record", "Bearer self", read, "self", nil},
+		{"self reads other record", "Bearer self", read, "other", types.ErrPermissionDenied},
+		{"self writes own record", "Bearer self", write, "self", nil},
+		{"self changes own status", "Bearer self", block, "self", types.ErrPermissionDenied},
+		{"support reads any record", "Bearer support", read, "other", nil},
+		{"support cannot write", "Bearer support", write, "other", types.ErrPermissionDenied},
+		{"admin changes status", "Bearer admin", block, "other", nil},
+		{"support changes only status", "Bearer support", blockOnly, "other", nil},
+		{"self changes only own status", "Bearer self", blockOnly, "self", types.ErrPermissionDenied},
+		{"without roles", "Bearer nobody", read, "nobody", types.ErrPermissionDenied},
+		{"any authenticated", "Bearer nobody", Rule{}, nil, nil},
+		{"blocked caller", "Bearer blocked", Rule{}, nil, types.ErrPermissionDenied},
+		{"deleted caller", "Bearer deleted", Rule{}, nil, types.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller, err := authorizer.Authorize(context.Background(), tt.authorization, tt.rule, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authorize() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && !tt.rule.Public && caller == nil {
+				t.Error("Authorize() returned nil caller")
+			}
+		})
+	}
+}
+
+func TestAuthorizer_AuthorizeTenant(t *testing.T) {
+	authorizer := NewAuthorizer(mockTokens{}, mockRoles{"admin": {types.RoleAdmin}})
+	rule := Rule{Permission: types.PermissionUsersRead}
+
+	caller, err := authorizer.Authorize(tenant.WithID(context.Background(), "acme"), "Bearer admin", rule, nil)
+	if err != nil {
+		t.Fatalf("Authorize() same tenant unexpected error = %v", err)
+	}
+
+	if caller.TenantID != "acme" {
+		t.Errorf("Authorize() caller tenant = %q, want %q", caller.TenantID, "acme")
+	}
+
+	_, err = authorizer.Authorize(tenant.WithID(context.Background(), "other"), "Bearer admin", rule, nil)
+	if !errors.Is(err, types.ErrInvalidToken) {
+		t.Errorf("Authorize() other tenant error = %v, want %v", err, types.ErrInvalidToken)
+	}
+}
@@ -0,0 +1,64 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/auth"
+	"github.com/obsessed-gopher/micro-service-guide/internal/logger"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// AuthorizationHeader - заголовок (metadata) с access токеном вида "Bearer <token>".
+const AuthorizationHeader = "authorization"
+
+// Auth проверяет доступ к каждому RPC по таблице правил policy,
+// ключ - полное имя метода. Методы без правила запрещены.
+// Вызывающий кладётся в контекст и доступен через auth.CallerFromContext.
+func Auth(authorizer *auth.Authorizer, policy map[string]auth.Rule) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rule, ok := policy[info.FullMethod]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, types.ErrPermissionDenied.Error())
+		}
+
+		caller, err := authorizer.Authorize(ctx, authorizationFromContext(ctx), rule, req)
+
+		switch {
+		case err == nil:
+		case errors.Is(err, types.ErrUnauthenticated), errors.Is(err, types.ErrInvalidToken):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, types.ErrPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		default:
+			slog.ErrorContext(ctx, "authorization failed", slog.Any("error", err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		if caller != nil {
+			ctx = auth.WithCaller(ctx, caller)
+			ctx = logger.WithAttrs(ctx, slog.String("caller_id", caller.UserID))
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func authorizationFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(AuthorizationHeader); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,82 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/logger"
+)
+
+// RequestIDHeader - заголовок (metadata) с идентификатором запроса.
+const RequestIDHeader = "x-request-id"
+
+// IDGenerator - интерфейс генератора ID.
+type IDGenerator interface {
+	Generate() string
+}
+
+// Logging логирует каждый RPC и кладёт в контекст поля запроса
+// (метод, адрес клиента, request ID), чтобы их подхватывали логи
+// usecase и репозиториев.
+func Logging(log *slog.Logger, idGen IDGenerator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		requestID := requestIDFromContext(ctx)
+		if requestID == "" {
+			requestID = idGen.Generate()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+		attrs := []slog.Attr{
+			slog.String("request_id", requestID),
+			slog.String("method", info.FullMethod),
+		}
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			attrs = append(attrs, slog.String("peer", p.Addr.String()))
+		}
+
+		ctx = logger.WithAttrs(ctx, attrs...)
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		level := slog.LevelInfo
+
+		switch code {
+		case codes.OK:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+
+		log.LogAttrs(ctx, level, "rpc finished",
+			slog.String("code", code.String()),
+			slog.Duration("duration", time.Since(start)),
+		)
+
+		return resp, err
+	}
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(RequestIDHeader); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
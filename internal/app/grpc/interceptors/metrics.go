@@ -0,0 +1,28 @@
+// Package interceptors содержит gRPC интерсепторы, общие для всех сервисов.
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// RPCMetrics - интерфейс сбора метрик RPC.
+type RPCMetrics interface {
+	Observe(method, code string, duration time.Duration)
+}
+
+// Metrics считает количество и латентность RPC в разрезе метода и кода ответа.
+func Metrics(metrics RPCMetrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		metrics.Observe(info.FullMethod, status.Code(err).String(), time.Since(start))
+
+		return resp, err
+	}
+}
@@ -0,0 +1,50 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/logger"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+)
+
+// Tenant определяет арендатора по metadata x-tenant-id и кладёт его
+// в контекст. Без заголовка используется defaultTenant, пустое значение
+// делает заголовок обязательным. Методы из exempt, например проверка
+// здоровья, выполняются без арендатора.
+func Tenant(defaultTenant string, exempt ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if slices.Contains(exempt, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		tenantID, err := tenant.Resolve(tenantFromContext(ctx), defaultTenant)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		ctx = tenant.WithID(ctx, tenantID)
+		ctx = logger.WithAttrs(ctx, slog.String("tenant_id", tenantID))
+
+		return handler(ctx, req)
+	}
+}
+
+func tenantFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(tenant.Header); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
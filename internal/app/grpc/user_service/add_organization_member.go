@@ -0,0 +1,37 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddOrganizationMember добавляет пользователя в организацию.
+func (s *Server) AddOrganizationMember(
+	ctx context.Context,
+	req *pb.AddOrganizationMemberRequest,
+) (*pb.AddOrganizationMemberResponse, error) {
+	if req.OrganizationId == "" {
+		return nil, status.Error(codes.InvalidArgument, "organization_id is required")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	member, err := s.orgUsecase.AddMember(ctx, models.AddMemberInput{
+		OrganizationID: req.OrganizationId,
+		UserID:         req.UserId,
+		Role:           types.MemberRole(req.Role),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.AddOrganizationMemberResponse{
+		Member: memberToProto(member),
+	}, nil
+}
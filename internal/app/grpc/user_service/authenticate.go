@@ -0,0 +1,48 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Authenticate проверяет учётные данные и открывает новую сессию.
+// Пользователю с MFA вместо токенов возвращается mfa_token для VerifyMFA.
+func (s *Server) Authenticate(ctx context.Context, req *pb.AuthenticateRequest) (*pb.AuthenticateResponse, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	if req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "password is required")
+	}
+
+	result, err := s.userUsecase.Authenticate(ctx, models.AuthenticateInput{
+		Email:    req.Email,
+		Password: req.Password,
+		Client:   clientInfoFromContext(ctx),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	if result.MFAChallenge != nil {
+		return &pb.AuthenticateResponse{
+			User:              userToProto(result.User),
+			MfaRequired:       true,
+			MfaToken:          result.MFAChallenge.Token,
+			MfaTokenExpiresAt: result.MFAChallenge.ExpiresAt.Unix(),
+		}, nil
+	}
+
+	return &pb.AuthenticateResponse{
+		User:                  userToProto(result.User),
+		AccessToken:           result.AccessToken.Token,
+		AccessTokenExpiresAt:  result.AccessToken.ExpiresAt.Unix(),
+		RefreshToken:          result.RefreshToken.Token,
+		RefreshTokenExpiresAt: result.RefreshToken.ExpiresAt.Unix(),
+		SessionId:             result.Session.ID,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ChangePassword меняет пароль пользователя после проверки текущего.
+func (s *Server) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.OldPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "old_password is required")
+	}
+	if req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "new_password is required")
+	}
+
+	user, err := s.userUsecase.ChangePassword(ctx, req.Id, models.ChangePasswordInput{
+		OldPassword:    req.OldPassword,
+		NewPassword:    req.NewPassword,
+		RevokeSessions: req.RevokeSessions,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.ChangePasswordResponse{
+		User: userToProto(user),
+	}, nil
+}
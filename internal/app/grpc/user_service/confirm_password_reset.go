@@ -0,0 +1,30 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConfirmPasswordReset устанавливает новый пароль по токену сброса.
+func (s *Server) ConfirmPasswordReset(ctx context.Context, req *pb.ConfirmPasswordResetRequest) (*pb.ConfirmPasswordResetResponse, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	if req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "new_password is required")
+	}
+
+	err := s.userUsecase.ConfirmPasswordReset(ctx, models.ConfirmPasswordResetInput{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.ConfirmPasswordResetResponse{}, nil
+}
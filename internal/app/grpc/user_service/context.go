@@ -0,0 +1,31 @@
+package user_service
+
+import (
+	"context"
+	"net"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// clientInfoFromContext извлекает user-agent и IP клиента из контекста запроса.
+func clientInfoFromContext(ctx context.Context) models.ClientInfo {
+	var info models.ClientInfo
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			info.UserAgent = values[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		info.IP = host
+	}
+
+	return info
+}
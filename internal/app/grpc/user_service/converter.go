@@ -1,6 +1,8 @@
 package user_service
 
 import (
+	"time"
+
 	"github.com/obsessed-gopher/micro-service-guide/internal/models"
 	"github.com/obsessed-gopher/micro-service-guide/internal/types"
 	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
@@ -9,12 +11,70 @@ import (
 // userToProto конвертирует бизнес-модель в proto.
 func userToProto(u *models.User) *pb.User {
 	return &pb.User{
-		Id:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		Status:    statusToProto(u.Status),
-		CreatedAt: u.CreatedAt.Unix(),
-		UpdatedAt: u.UpdatedAt.Unix(),
+		Id:                u.ID,
+		Email:             u.Email,
+		Name:              u.Name,
+		Status:            statusToProto(u.EffectiveStatus()),
+		CreatedAt:         u.CreatedAt.Unix(),
+		UpdatedAt:         u.UpdatedAt.Unix(),
+		Version:           u.Version,
+		DeletedAt:         unixOrZero(u.DeletedAt),
+		PasswordChangedAt: u.PasswordChangedAt.Unix(),
+		EmailVerifiedAt:   unixOrZero(u.EmailVerifiedAt),
+		MfaEnabled:        u.IsMFAEnabled(),
+		TenantId:          u.TenantID,
+	}
+}
+
+// rolesToProto конвертирует роли в строки.
+func rolesToProto(roles []types.Role) []string {
+	result := make([]string, len(roles))
+	for i, role := range roles {
+		result[i] = string(role)
+	}
+	return result
+}
+
+// unixOrZero возвращает unix время или 0 для незаданного времени.
+func unixOrZero(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+// sessionToProto конвертирует сессию в proto.
+func sessionToProto(s *models.Session) *pb.Session {
+	return &pb.Session{
+		Id:         s.ID,
+		UserId:     s.UserID,
+		UserAgent:  s.UserAgent,
+		Ip:         s.IP,
+		CreatedAt:  s.CreatedAt.Unix(),
+		LastUsedAt: s.LastUsedAt.Unix(),
+		ExpiresAt:  s.ExpiresAt.Unix(),
+	}
+}
+
+// organizationToProto конвертирует организацию в proto.
+func organizationToProto(o *models.Organization) *pb.Organization {
+	return &pb.Organization{
+		Id:        o.ID,
+		TenantId:  o.TenantID,
+		Name:      o.Name,
+		CreatedAt: o.CreatedAt.Unix(),
+		UpdatedAt: o.UpdatedAt.Unix(),
+	}
+}
+
+// memberToProto конвертирует членство в организации в proto.
+func memberToProto(m *models.Membership) *pb.OrganizationMember {
+	return &pb.OrganizationMember{
+		OrganizationId: m.OrganizationID,
+		UserId:         m.UserID,
+		Role:           string(m.Role),
+		CreatedAt:      m.CreatedAt.Unix(),
 	}
 }
 
@@ -27,6 +87,8 @@ func statusToProto(s types.UserStatus) pb.UserStatus {
 		return pb.UserStatus_USER_STATUS_INACTIVE
 	case types.UserStatusBlocked:
 		return pb.UserStatus_USER_STATUS_BLOCKED
+	case types.UserStatusDeleted:
+		return pb.UserStatus_USER_STATUS_DELETED
 	default:
 		return pb.UserStatus_USER_STATUS_UNSPECIFIED
 	}
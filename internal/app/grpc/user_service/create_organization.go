@@ -0,0 +1,26 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateOrganization создаёт организацию.
+func (s *Server) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.CreateOrganizationResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	org, err := s.orgUsecase.Create(ctx, models.CreateOrganizationInput{Name: req.Name})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.CreateOrganizationResponse{
+		Organization: organizationToProto(org),
+	}, nil
+}
@@ -0,0 +1,22 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeleteOrganization удаляет организацию.
+func (s *Server) DeleteOrganization(ctx context.Context, req *pb.DeleteOrganizationRequest) (*pb.DeleteOrganizationResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.orgUsecase.Delete(ctx, req.Id); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.DeleteOrganizationResponse{}, nil
+}
@@ -0,0 +1,25 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DisableTOTP выключает MFA по действующему коду или коду восстановления.
+func (s *Server) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	if err := s.userUsecase.DisableTOTP(ctx, req.Id, req.Code); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.DisableTOTPResponse{}, nil
+}
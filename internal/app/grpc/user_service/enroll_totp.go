@@ -0,0 +1,26 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnrollTOTP выпускает секрет TOTP для подключения приложения-аутентификатора.
+func (s *Server) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	enrollment, err := s.userUsecase.EnrollTOTP(ctx, req.Id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Secret: enrollment.Secret,
+		Uri:    enrollment.URI,
+	}, nil
+}
@@ -1,23 +1,102 @@
 package user_service
 
 import (
+	"errors"
+	"time"
+
 	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // mapError конвертирует бизнес-ошибки в gRPC статусы.
+// Usecase оборачивает ошибки через %w, поэтому сравниваем через errors.Is.
 func mapError(err error) error {
-	switch err {
-	case types.ErrUserNotFound:
+	var (
+		policyErr    *types.PasswordPolicyError
+		throttledErr *types.LoginThrottledError
+	)
+
+	switch {
+	case errors.As(err, &policyErr):
+		return passwordPolicyStatus(policyErr)
+	case errors.As(err, &throttledErr):
+		return loginThrottledStatus(throttledErr)
+	case errors.Is(err, types.ErrUserNotFound):
+		return status.Error(codes.NotFound, types.ErrUserNotFound.Error())
+	case errors.Is(err, types.ErrSessionNotFound):
+		return status.Error(codes.NotFound, types.ErrSessionNotFound.Error())
+	case errors.Is(err, types.ErrOrganizationNotFound), errors.Is(err, types.ErrMemberNotFound):
 		return status.Error(codes.NotFound, err.Error())
-	case types.ErrUserAlreadyExists:
-		return status.Error(codes.AlreadyExists, err.Error())
-	case types.ErrInvalidEmail, types.ErrInvalidPassword:
+	case errors.Is(err, types.ErrMemberAlreadyExists):
+		return status.Error(codes.AlreadyExists, types.ErrMemberAlreadyExists.Error())
+	case errors.Is(err, types.ErrUserAlreadyExists):
+		return status.Error(codes.AlreadyExists, types.ErrUserAlreadyExists.Error())
+	case errors.Is(err, types.ErrVersionConflict):
+		return status.Error(codes.Aborted, types.ErrVersionConflict.Error())
+	case errors.Is(err, types.ErrInvalidEmail), errors.Is(err, types.ErrInvalidPassword),
+		errors.Is(err, types.ErrInvalidPageToken), errors.Is(err, types.ErrInvalidOrderBy),
+		errors.Is(err, types.ErrEmptySearchQuery), errors.Is(err, types.ErrWrongPassword),
+		errors.Is(err, types.ErrInvalidMFACode), errors.Is(err, types.ErrInvalidRole),
+		errors.Is(err, types.ErrTenantRequired), errors.Is(err, types.ErrInvalidTenant),
+		errors.Is(err, types.ErrInvalidOrganizationName), errors.Is(err, types.ErrInvalidMemberRole),
+		errors.Is(err, types.ErrInvalidStatus):
 		return status.Error(codes.InvalidArgument, err.Error())
-	case types.ErrUserBlocked:
+	case errors.Is(err, types.ErrUserBlocked), errors.Is(err, types.ErrUserInactive),
+		errors.Is(err, types.ErrMFAAlreadyEnabled), errors.Is(err, types.ErrMFANotEnabled):
 		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, types.ErrInvalidCredentials), errors.Is(err, types.ErrInvalidToken),
+		errors.Is(err, types.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, types.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, types.ErrTooManyAttempts):
+		return status.Error(codes.ResourceExhausted, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal error")
 	}
 }
+
+// passwordPolicyStatus возвращает InvalidArgument с нарушениями политики
+// паролей в деталях: BadRequest с описаниями и ErrorInfo с причинами.
+func passwordPolicyStatus(err *types.PasswordPolicyError) error {
+	badRequest := &errdetails.BadRequest{}
+	info := &errdetails.ErrorInfo{
+		Reason:   "PASSWORD_POLICY_VIOLATION",
+		Domain:   pb.UserService_ServiceDesc.ServiceName,
+		Metadata: make(map[string]string, len(err.Violations)),
+	}
+
+	for _, v := range err.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       "password",
+			Description: v.Message,
+		})
+		info.Metadata[v.Reason] = v.Message
+	}
+
+	st, detailsErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(badRequest, info)
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return st.Err()
+}
+
+// loginThrottledStatus возвращает ResourceExhausted с RetryInfo,
+// чтобы клиент знал, когда можно повторить попытку входа.
+func loginThrottledStatus(err *types.LoginThrottledError) error {
+	retry := &errdetails.RetryInfo{
+		RetryDelay: durationpb.New(time.Duration(err.RetryAfterSeconds()) * time.Second),
+	}
+
+	st, detailsErr := status.New(codes.ResourceExhausted, err.Error()).WithDetails(retry)
+	if detailsErr != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return st.Err()
+}
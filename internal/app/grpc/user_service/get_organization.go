@@ -0,0 +1,25 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetOrganization возвращает организацию по ID.
+func (s *Server) GetOrganization(ctx context.Context, req *pb.GetOrganizationRequest) (*pb.GetOrganizationResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	org, err := s.orgUsecase.GetByID(ctx, req.Id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.GetOrganizationResponse{
+		Organization: organizationToProto(org),
+	}, nil
+}
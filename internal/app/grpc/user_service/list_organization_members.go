@@ -0,0 +1,33 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListOrganizationMembers возвращает участников организации.
+func (s *Server) ListOrganizationMembers(
+	ctx context.Context,
+	req *pb.ListOrganizationMembersRequest,
+) (*pb.ListOrganizationMembersResponse, error) {
+	if req.OrganizationId == "" {
+		return nil, status.Error(codes.InvalidArgument, "organization_id is required")
+	}
+
+	members, err := s.orgUsecase.ListMembers(ctx, req.OrganizationId)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoMembers := make([]*pb.OrganizationMember, len(members))
+	for i, member := range members {
+		protoMembers[i] = memberToProto(member)
+	}
+
+	return &pb.ListOrganizationMembersResponse{
+		Members: protoMembers,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+)
+
+// ListOrganizations возвращает список организаций.
+func (s *Server) ListOrganizations(ctx context.Context, req *pb.ListOrganizationsRequest) (*pb.ListOrganizationsResponse, error) {
+	result, err := s.orgUsecase.List(ctx, usecases.OrganizationListFilter{
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoOrgs := make([]*pb.Organization, len(result.Organizations))
+	for i, org := range result.Organizations {
+		protoOrgs[i] = organizationToProto(org)
+	}
+
+	return &pb.ListOrganizationsResponse{
+		Organizations: protoOrgs,
+		Total:         int32(result.Total),
+	}, nil
+}
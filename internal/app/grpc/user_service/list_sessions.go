@@ -0,0 +1,30 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListSessions возвращает активные сессии пользователя.
+func (s *Server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	sessions, err := s.userUsecase.ListSessions(ctx, req.UserId)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoSessions := make([]*pb.Session, len(sessions))
+	for i, session := range sessions {
+		protoSessions[i] = sessionToProto(session)
+	}
+
+	return &pb.ListSessionsResponse{
+		Sessions: protoSessions,
+	}, nil
+}
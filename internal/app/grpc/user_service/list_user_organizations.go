@@ -0,0 +1,37 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListUserOrganizations возвращает организации пользователя и его роли в них.
+func (s *Server) ListUserOrganizations(
+	ctx context.Context,
+	req *pb.ListUserOrganizationsRequest,
+) (*pb.ListUserOrganizationsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	orgs, err := s.orgUsecase.ListUserOrganizations(ctx, req.UserId)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoOrgs := make([]*pb.UserOrganization, len(orgs))
+	for i, org := range orgs {
+		protoOrgs[i] = &pb.UserOrganization{
+			Organization: organizationToProto(org.Organization),
+			Role:         string(org.Role),
+			JoinedAt:     org.JoinedAt.Unix(),
+		}
+	}
+
+	return &pb.ListUserOrganizationsResponse{
+		Organizations: protoOrgs,
+	}, nil
+}
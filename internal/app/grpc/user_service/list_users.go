@@ -2,50 +2,116 @@ package user_service
 
 import (
 	"context"
+	"time"
 
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
 	"github.com/obsessed-gopher/micro-service-guide/internal/types"
 	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
 	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ListUsers возвращает список пользователей.
 func (s *Server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
 	filter := usecases.ListFilter{
-		Limit:  int(req.Limit),
-		Offset: int(req.Offset),
+		Limit:     int(req.Limit),
+		Offset:    int(req.Offset),
+		PageToken: req.PageToken,
+		OrderBy:   req.OrderBy,
 	}
 
 	if req.Filter != nil {
-		filter.IDs = req.Filter.Ids
-		filter.Emails = req.Filter.Emails
-		filter.Statuses = statusesFromProto(req.Filter.Statuses)
+		filters, err := userFilterFromProto(req.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Filters = filters
 	}
 
-	users, total, err := s.userUsecase.List(ctx, filter)
+	result, err := s.userUsecase.List(ctx, filter)
 	if err != nil {
 		return nil, mapError(err)
 	}
 
-	protoUsers := make([]*pb.User, len(users))
-	for i, u := range users {
+	protoUsers := make([]*pb.User, len(result.Users))
+	for i, u := range result.Users {
 		protoUsers[i] = userToProto(u)
 	}
 
 	return &pb.ListUsersResponse{
-		Users: protoUsers,
-		Total: int32(total),
+		Users:         protoUsers,
+		Total:         int32(result.Total),
+		NextPageToken: result.NextPageToken,
+	}, nil
+}
+
+// userFilterFromProto конвертирует фильтр пользователей. Невалидные
+// статусы, включая DELETED, отклоняются с InvalidArgument, как в HTTP шлюзе.
+func userFilterFromProto(f *pb.UserFilter) (models.UserFilter, error) {
+	statuses, ok := statusesFromProto(f.Statuses)
+	if !ok {
+		return models.UserFilter{}, status.Error(codes.InvalidArgument, "invalid status")
+	}
+
+	excludeStatuses, ok := statusesFromProto(f.ExcludeStatuses)
+	if !ok {
+		return models.UserFilter{}, status.Error(codes.InvalidArgument, "invalid exclude_status")
+	}
+
+	return models.UserFilter{
+		IDs:             f.Ids,
+		Emails:          f.Emails,
+		Statuses:        statuses,
+		ExcludeStatuses: excludeStatuses,
+		EmailDomains:    f.EmailDomains,
+		EmailMatch:      stringMatchFromProto(f.EmailMatch),
+		NameMatch:       stringMatchFromProto(f.NameMatch),
+		CreatedAt:       timeRangeFromProto(f.CreatedFrom, f.CreatedTo),
+		UpdatedAt:       timeRangeFromProto(f.UpdatedFrom, f.UpdatedTo),
+		IncludeDeleted:  f.IncludeDeleted,
 	}, nil
 }
 
-func statusesFromProto(statuses []pb.UserStatus) []types.UserStatus {
+func stringMatchFromProto(m *pb.StringMatch) models.StringMatch {
+	if m == nil {
+		return models.StringMatch{}
+	}
+
+	return models.StringMatch{Value: m.Value, Prefix: m.Prefix}
+}
+
+func timeRangeFromProto(from, to *int64) models.TimeRange {
+	var r models.TimeRange
+
+	if from != nil {
+		t := time.Unix(*from, 0)
+		r.From = &t
+	}
+
+	if to != nil {
+		t := time.Unix(*to, 0)
+		r.To = &t
+	}
+
+	return r
+}
+
+// statusesFromProto конвертирует статусы фильтра. Возвращает false,
+// если среди них есть невалидный.
+func statusesFromProto(statuses []pb.UserStatus) ([]types.UserStatus, bool) {
 	if len(statuses) == 0 {
-		return nil
+		return nil, true
 	}
 
 	result := make([]types.UserStatus, len(statuses))
 	for i, s := range statuses {
 		result[i] = statusFromProto(s)
+		if !result[i].IsValid() {
+			return nil, false
+		}
 	}
 
-	return result
+	return result, true
 }
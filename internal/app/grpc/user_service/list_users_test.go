@@ -0,0 +1,39 @@
+package user_service
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_ListUsers_InvalidStatus(t *testing.T) {
+	// Невалидный статус в фильтре отклоняется до обращения к бизнес-логике.
+	server := &Server{}
+
+	tests := []struct {
+		name   string
+		filter *pb.UserFilter
+	}{
+		{"deleted", &pb.UserFilter{Statuses: []pb.UserStatus{pb.UserStatus_USER_STATUS_DELETED}}},
+		{"unspecified", &pb.UserFilter{Statuses: []pb.UserStatus{pb.UserStatus_USER_STATUS_UNSPECIFIED}}},
+		{"unknown", &pb.UserFilter{Statuses: []pb.UserStatus{pb.UserStatus(42)}}},
+		{"exclude deleted", &pb.UserFilter{ExcludeStatuses: []pb.UserStatus{pb.UserStatus_USER_STATUS_DELETED}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.ListUsers(context.Background(), &pb.ListUsersRequest{Filter: tt.filter})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("ListUsers() code = %v, want %v", status.Code(err), codes.InvalidArgument)
+			}
+
+			_, err = server.SearchUsers(context.Background(), &pb.SearchUsersRequest{Query: "alice", Filter: tt.filter})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("SearchUsers() code = %v, want %v", status.Code(err), codes.InvalidArgument)
+			}
+		})
+	}
+}
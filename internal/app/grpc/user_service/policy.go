@@ -0,0 +1,123 @@
+package user_service
+
+import (
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/auth"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+)
+
+// Policy возвращает правила доступа к методам сервиса для interceptors.Auth.
+// Ключ - полное имя метода.
+func Policy() map[string]auth.Rule {
+	rules := map[string]auth.Rule{
+		// Регистрация, вход и сценарии по одноразовым токенам доступны без токена.
+		"CreateUser":              {Public: true},
+		"Authenticate":            {Public: true},
+		"VerifyMFA":               {Public: true},
+		"RefreshToken":            {Public: true},
+		"RequestPasswordReset":    {Public: true},
+		"ConfirmPasswordReset":    {Public: true},
+		"VerifyEmail":             {Public: true},
+		"ResendEmailVerification": {Public: true},
+
+		"GetUser": {
+			Permission: types.PermissionUsersRead,
+			Self:       selfByID(func(r *pb.GetUserRequest) string { return r.Id }),
+		},
+		"UpdateUser": {
+			PermissionFor: updateUserPermission,
+			Self:          selfByID(func(r *pb.UpdateUserRequest) string { return r.Id }),
+			Extra:         updateUserExtra,
+		},
+		"ListUsers":   {Permission: types.PermissionUsersRead},
+		"SearchUsers": {Permission: types.PermissionUsersRead},
+		"DeleteUser":  {Permission: types.PermissionUsersDelete},
+		"RestoreUser": {Permission: types.PermissionUsersDelete},
+		"UnlockUser":  {Permission: types.PermissionUsersBlock},
+		"SetPassword": {Permission: types.PermissionUsersWrite},
+		"AssignRole":  {Permission: types.PermissionUsersRoles},
+		"RevokeRole":  {Permission: types.PermissionUsersRoles},
+
+		"CreateOrganization":       {Permission: types.PermissionOrgsWrite},
+		"GetOrganization":          {Permission: types.PermissionOrgsRead},
+		"ListOrganizations":        {Permission: types.PermissionOrgsRead},
+		"DeleteOrganization":       {Permission: types.PermissionOrgsWrite},
+		"AddOrganizationMember":    {Permission: types.PermissionOrgsWrite},
+		"RemoveOrganizationMember": {Permission: types.PermissionOrgsWrite},
+		"ListOrganizationMembers":  {Permission: types.PermissionOrgsRead},
+		"ListUserOrganizations": {
+			Permission: types.PermissionOrgsRead,
+			Self:       selfByID(func(r *pb.ListUserOrganizationsRequest) string { return r.UserId }),
+		},
+
+		"ChangePassword": {
+			Permission: types.PermissionUsersWrite,
+			Self:       selfByID(func(r *pb.ChangePasswordRequest) string { return r.Id }),
+		},
+		"EnrollTOTP": {
+			Permission: types.PermissionUsersWrite,
+			Self:       selfByID(func(r *pb.EnrollTOTPRequest) string { return r.Id }),
+		},
+		"ConfirmTOTP": {
+			Permission: types.PermissionUsersWrite,
+			Self:       selfByID(func(r *pb.ConfirmTOTPRequest) string { return r.Id }),
+		},
+		"DisableTOTP": {
+			Permission: types.PermissionUsersWrite,
+			Self:       selfByID(func(r *pb.DisableTOTPRequest) string { return r.Id }),
+		},
+		"ListSessions": {
+			Permission: types.PermissionUsersRead,
+			Self:       selfByID(func(r *pb.ListSessionsRequest) string { return r.UserId }),
+		},
+		"RevokeAllSessions": {
+			Permission: types.PermissionUsersWrite,
+			Self:       selfByID(func(r *pb.RevokeAllSessionsRequest) string { return r.UserId }),
+		},
+		// Владелец сессии определяется только по токену: без права на
+		// любых пользователей можно отозвать лишь текущую сессию (выход).
+		"RevokeSession": {
+			Permission: types.PermissionUsersWrite,
+			Self: func(caller *auth.Caller, req any) bool {
+				r, ok := req.(*pb.RevokeSessionRequest)
+				return ok && caller.SessionID != "" && r.SessionId == caller.SessionID
+			},
+		},
+	}
+
+	policy := make(map[string]auth.Rule, len(rules))
+	for method, rule := range rules {
+		policy["/"+pb.UserService_ServiceDesc.ServiceName+"/"+method] = rule
+	}
+
+	return policy
+}
+
+// selfByID возвращает проверку того, что запрос касается записи вызывающего.
+func selfByID[T any](id func(req T) string) func(caller *auth.Caller, req any) bool {
+	return func(caller *auth.Caller, req any) bool {
+		r, ok := req.(T)
+		return ok && id(r) == caller.UserID
+	}
+}
+
+// updateUserPermission требует права на изменение пользователя для смены
+// email и имени. Смена одного статуса проверяется только updateUserExtra,
+// чтобы блокировать пользователей могла роль без права на изменение.
+func updateUserPermission(req any) types.Permission {
+	if r, ok := req.(*pb.UpdateUserRequest); ok && r.Status != nil && r.Email == nil && r.Name == nil {
+		return ""
+	}
+
+	return types.PermissionUsersWrite
+}
+
+// updateUserExtra требует права на блокировку для смены статуса,
+// иначе заблокированный пользователь мог бы разблокировать себя сам.
+func updateUserExtra(req any) []types.Permission {
+	if r, ok := req.(*pb.UpdateUserRequest); ok && r.Status != nil {
+		return []types.Permission{types.PermissionUsersBlock}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package user_service
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/auth"
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/grpc/interceptors"
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+)
+
+func TestPolicy_CoversAllMethods(t *testing.T) {
+	policy := Policy()
+
+	for _, method := range pb.UserService_ServiceDesc.Methods {
+		name := "/" + pb.UserService_ServiceDesc.ServiceName + "/" + method.MethodName
+		if _, ok := policy[name]; !ok {
+			t.Errorf("no access rule for %s", name)
+		}
+	}
+
+	if len(policy) != len(pb.UserService_ServiceDesc.Methods) {
+		t.Errorf("policy has %d rules for %d methods", len(policy), len(pb.UserService_ServiceDesc.Methods))
+	}
+}
+
+// policyTokens принимает токен вида "<user_id>" и выдаёт его как subject.
+type policyTokens struct{}
+
+func (policyTokens) ParseAccessToken(token string) (*models.TokenClaims, error) {
+	return &models.TokenClaims{UserID: token}, nil
+}
+
+type policyRoles map[string][]types.Role
+
+func (m policyRoles) UserRoles(ctx context.Context, userID string) ([]types.Role, error) {
+	return m[userID], nil
+}
+
+func TestPolicy_UpdateUser(t *testing.T) {
+	authorizer := auth.NewAuthorizer(policyTokens{}, policyRoles{
+		"support": {types.RoleSupport},
+		"self":    {types.RoleSelfService},
+	})
+	interceptor := interceptors.Auth(authorizer, Policy())
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + pb.UserService_ServiceDesc.ServiceName + "/UpdateUser"}
+	handler := func(ctx context.Context, req any) (any, error) { return &pb.UpdateUserResponse{}, nil }
+
+	blocked := pb.UserStatus_USER_STATUS_BLOCKED
+	name := "New Name"
+
+	tests := []struct {
+		name     string
+		caller   string
+		req      *pb.UpdateUserRequest
+		wantCode codes.Code
+	}{
+		{"support blocks other user", "support", &pb.UpdateUserRequest{Id: "other", Status: &blocked}, codes.OK},
+		{"support renames other user", "support", &pb.UpdateUserRequest{Id: "other", Name: &name}, codes.PermissionDenied},
+		{"support blocks and renames", "support", &pb.UpdateUserRequest{Id: "other", Name: &name, Status: &blocked}, codes.PermissionDenied},
+		{"self renames itself", "self", &pb.UpdateUserRequest{Id: "self", Name: &name}, codes.OK},
+		{"self changes own status", "self", &pb.UpdateUserRequest{Id: "self", Status: &blocked}, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs(interceptors.AuthorizationHeader, "Bearer "+tt.caller))
+
+			_, err := interceptor(ctx, tt.req, info, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("UpdateUser code = %v, want %v (err = %v)", status.Code(err), tt.wantCode, err)
+			}
+		})
+	}
+}
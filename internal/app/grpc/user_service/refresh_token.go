@@ -0,0 +1,29 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RefreshToken обменивает refresh токен на новую пару токенов.
+func (s *Server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+
+	result, err := s.userUsecase.Refresh(ctx, req.RefreshToken, clientInfoFromContext(ctx))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.RefreshTokenResponse{
+		AccessToken:           result.AccessToken.Token,
+		AccessTokenExpiresAt:  result.AccessToken.ExpiresAt.Unix(),
+		RefreshToken:          result.RefreshToken.Token,
+		RefreshTokenExpiresAt: result.RefreshToken.ExpiresAt.Unix(),
+		SessionId:             result.Session.ID,
+	}, nil
+}
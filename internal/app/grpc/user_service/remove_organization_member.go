@@ -0,0 +1,28 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RemoveOrganizationMember исключает пользователя из организации.
+func (s *Server) RemoveOrganizationMember(
+	ctx context.Context,
+	req *pb.RemoveOrganizationMemberRequest,
+) (*pb.RemoveOrganizationMemberResponse, error) {
+	if req.OrganizationId == "" {
+		return nil, status.Error(codes.InvalidArgument, "organization_id is required")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if err := s.orgUsecase.RemoveMember(ctx, req.OrganizationId, req.UserId); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.RemoveOrganizationMemberResponse{}, nil
+}
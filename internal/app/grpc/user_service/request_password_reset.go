@@ -0,0 +1,22 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestPasswordReset отправляет пользователю токен сброса пароля.
+func (s *Server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	if err := s.userUsecase.RequestPasswordReset(ctx, req.Email); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
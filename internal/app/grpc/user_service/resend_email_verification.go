@@ -0,0 +1,22 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResendEmailVerification повторно отправляет токен подтверждения email.
+func (s *Server) ResendEmailVerification(ctx context.Context, req *pb.ResendEmailVerificationRequest) (*pb.ResendEmailVerificationResponse, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	if err := s.userUsecase.ResendEmailVerification(ctx, req.Email); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.ResendEmailVerificationResponse{}, nil
+}
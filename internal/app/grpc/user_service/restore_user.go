@@ -0,0 +1,25 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RestoreUser восстанавливает мягко удалённого пользователя.
+func (s *Server) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.RestoreUserResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	user, err := s.userUsecase.Restore(ctx, req.Id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.RestoreUserResponse{
+		User: userToProto(user),
+	}, nil
+}
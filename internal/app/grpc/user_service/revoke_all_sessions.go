@@ -0,0 +1,25 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeAllSessions отзывает все сессии пользователя.
+func (s *Server) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	count, err := s.userUsecase.RevokeAllSessions(ctx, req.UserId)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.RevokeAllSessionsResponse{
+		Revoked: int32(count),
+	}, nil
+}
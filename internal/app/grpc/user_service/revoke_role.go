@@ -0,0 +1,29 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeRole отзывает роль пользователя.
+func (s *Server) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.RevokeRoleResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.Role == "" {
+		return nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+
+	roles, err := s.userUsecase.RevokeRole(ctx, req.Id, types.Role(req.Role))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.RevokeRoleResponse{
+		Roles: rolesToProto(roles),
+	}, nil
+}
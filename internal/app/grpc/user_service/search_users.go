@@ -0,0 +1,47 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SearchUsers ищет пользователей по имени и email.
+func (s *Server) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	if req.Query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	filter := usecases.SearchFilter{
+		Query:  req.Query,
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+	}
+
+	if req.Filter != nil {
+		filters, err := userFilterFromProto(req.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Filters = filters
+	}
+
+	result, err := s.userUsecase.Search(ctx, filter)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoUsers := make([]*pb.User, len(result.Users))
+	for i, u := range result.Users {
+		protoUsers[i] = userToProto(u)
+	}
+
+	return &pb.SearchUsersResponse{
+		Users: protoUsers,
+		Total: int32(result.Total),
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
 	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
 	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
 )
@@ -15,18 +16,52 @@ type UserUsecase interface {
 	GetByID(ctx context.Context, id string) (*models.User, error)
 	Update(ctx context.Context, id string, input models.UpdateUserInput) (*models.User, error)
 	Delete(ctx context.Context, filter models.UserFilter) (int, error)
-	List(ctx context.Context, filter usecases.ListFilter) ([]*models.User, int, error)
+	Restore(ctx context.Context, id string) (*models.User, error)
+	ChangePassword(ctx context.Context, id string, input models.ChangePasswordInput) (*models.User, error)
+	SetPassword(ctx context.Context, id string, input models.SetPasswordInput) (*models.User, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, input models.ConfirmPasswordResetInput) error
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+	ResendEmailVerification(ctx context.Context, email string) error
+	List(ctx context.Context, filter usecases.ListFilter) (*usecases.ListResult, error)
+	Search(ctx context.Context, filter usecases.SearchFilter) (*usecases.ListResult, error)
+	Authenticate(ctx context.Context, input models.AuthenticateInput) (*models.AuthResult, error)
+	EnrollTOTP(ctx context.Context, userID string) (*models.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID, code string) error
+	UnlockUser(ctx context.Context, id string) (*models.User, error)
+	AssignRole(ctx context.Context, userID string, role types.Role) ([]types.Role, error)
+	RevokeRole(ctx context.Context, userID string, role types.Role) ([]types.Role, error)
+	VerifyMFA(ctx context.Context, input models.VerifyMFAInput) (*models.AuthResult, error)
+	Refresh(ctx context.Context, refreshToken string, client models.ClientInfo) (*models.AuthResult, error)
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID string) (int, error)
+	ListSessions(ctx context.Context, userID string) ([]*models.Session, error)
+}
+
+// OrganizationUsecase - интерфейс бизнес-логики организаций.
+type OrganizationUsecase interface {
+	Create(ctx context.Context, input models.CreateOrganizationInput) (*models.Organization, error)
+	GetByID(ctx context.Context, id string) (*models.Organization, error)
+	List(ctx context.Context, filter usecases.OrganizationListFilter) (*usecases.OrganizationListResult, error)
+	Delete(ctx context.Context, id string) error
+	AddMember(ctx context.Context, input models.AddMemberInput) (*models.Membership, error)
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	ListMembers(ctx context.Context, orgID string) ([]*models.Membership, error)
+	ListUserOrganizations(ctx context.Context, userID string) ([]*models.UserOrganization, error)
 }
 
 // Server - gRPC сервер сервиса пользователей.
 type Server struct {
 	pb.UnimplementedUserServiceServer
 	userUsecase UserUsecase
+	orgUsecase  OrganizationUsecase
 }
 
 // NewServer создаёт новый сервер.
-func NewServer(userUsecase UserUsecase) *Server {
+func NewServer(userUsecase UserUsecase, orgUsecase OrganizationUsecase) *Server {
 	return &Server{
 		userUsecase: userUsecase,
+		orgUsecase:  orgUsecase,
 	}
 }
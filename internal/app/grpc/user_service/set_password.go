@@ -0,0 +1,32 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetPassword устанавливает пароль пользователя без проверки текущего (для администраторов).
+func (s *Server) SetPassword(ctx context.Context, req *pb.SetPasswordRequest) (*pb.SetPasswordResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "password is required")
+	}
+
+	user, err := s.userUsecase.SetPassword(ctx, req.Id, models.SetPasswordInput{
+		Password:       req.Password,
+		RevokeSessions: req.RevokeSessions,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.SetPasswordResponse{
+		User: userToProto(user),
+	}, nil
+}
@@ -0,0 +1,25 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnlockUser снимает задержки и блокировку входа после неудачных попыток.
+func (s *Server) UnlockUser(ctx context.Context, req *pb.UnlockUserRequest) (*pb.UnlockUserResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	user, err := s.userUsecase.UnlockUser(ctx, req.Id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.UnlockUserResponse{
+		User: userToProto(user),
+	}, nil
+}
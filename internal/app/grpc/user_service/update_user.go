@@ -24,8 +24,14 @@ func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb
 	}
 	if req.Status != nil {
 		st := statusFromProto(*req.Status)
+		if !st.IsValid() {
+			return nil, status.Error(codes.InvalidArgument, "invalid status")
+		}
 		input.Status = &st
 	}
+	if req.Version != nil {
+		input.ExpectedVersion = req.Version
+	}
 
 	user, err := s.userUsecase.Update(ctx, req.Id, input)
 	if err != nil {
@@ -0,0 +1,32 @@
+package user_service
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_UpdateUser_InvalidStatus(t *testing.T) {
+	// Невалидный статус отклоняется до обращения к бизнес-логике.
+	server := &Server{}
+
+	tests := []struct {
+		name   string
+		status pb.UserStatus
+	}{
+		{"unspecified", pb.UserStatus_USER_STATUS_UNSPECIFIED},
+		{"deleted", pb.UserStatus_USER_STATUS_DELETED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.UpdateUser(context.Background(), &pb.UpdateUserRequest{Id: "user-id", Status: &tt.status})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("UpdateUser() code = %v, want %v", status.Code(err), codes.InvalidArgument)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package user_service
+
+import (
+	"context"
+
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyEmail подтверждает email пользователя по токену из письма.
+func (s *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	user, err := s.userUsecase.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.VerifyEmailResponse{
+		User: userToProto(user),
+	}, nil
+}
@@ -0,0 +1,38 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	pb "github.com/obsessed-gopher/micro-service-guide/pkg/pb/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyMFA завершает вход пользователя с MFA и открывает новую сессию.
+func (s *Server) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.VerifyMFAResponse, error) {
+	if req.MfaToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "mfa_token is required")
+	}
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	result, err := s.userUsecase.VerifyMFA(ctx, models.VerifyMFAInput{
+		Token:  req.MfaToken,
+		Code:   req.Code,
+		Client: clientInfoFromContext(ctx),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.VerifyMFAResponse{
+		User:                  userToProto(result.User),
+		AccessToken:           result.AccessToken.Token,
+		AccessTokenExpiresAt:  result.AccessToken.ExpiresAt.Unix(),
+		RefreshToken:          result.RefreshToken.Token,
+		RefreshTokenExpiresAt: result.RefreshToken.ExpiresAt.Unix(),
+		SessionId:             result.Session.ID,
+	}, nil
+}
@@ -0,0 +1,130 @@
+// Package health содержит проверку готовности сервиса для gRPC health checking.
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Pinger - зависимость, доступность которой определяет готовность сервиса.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Checker периодически пингует зависимости и выставляет статус
+// в gRPC health сервере. До первой успешной проверки и после Shutdown
+// сервис отдаёт NOT_SERVING.
+type Checker struct {
+	server   *health.Server
+	pinger   Pinger
+	interval time.Duration
+	timeout  time.Duration
+	services []string
+	ready    atomic.Bool
+	stopped  atomic.Bool
+}
+
+// Значения по умолчанию для незаданных интервалов.
+const (
+	defaultInterval = 10 * time.Second
+	defaultTimeout  = 2 * time.Second
+)
+
+// NewChecker создаёт новую проверку готовности.
+// services - имена gRPC сервисов, статус которых нужно выставлять
+// (общий статус сервера "" выставляется всегда).
+func NewChecker(server *health.Server, pinger Pinger, interval, timeout time.Duration, services ...string) *Checker {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	c := &Checker{
+		server:   server,
+		pinger:   pinger,
+		interval: interval,
+		timeout:  timeout,
+		services: append([]string{""}, services...),
+	}
+
+	c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return c
+}
+
+// Run выполняет проверки до отмены ctx.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown переводит сервис в NOT_SERVING навсегда.
+// Вызывается при получении SIGTERM, чтобы балансировщик перестал слать трафик.
+func (c *Checker) Shutdown() {
+	c.stopped.Store(true)
+	c.ready.Store(false)
+	c.server.Shutdown()
+}
+
+// Ready сообщает, готов ли сервис принимать трафик.
+func (c *Checker) Ready() bool {
+	return c.ready.Load()
+}
+
+// ServeHTTP отдаёт статус готовности для HTTP проб: 200 или 503.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	if !c.Ready() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *Checker) check(ctx context.Context) {
+	if c.stopped.Load() {
+		return
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	err := c.pinger.Ping(pingCtx)
+	cancel()
+
+	ready := err == nil
+	if c.ready.Swap(ready) == ready {
+		return
+	}
+
+	if ready {
+		slog.InfoContext(ctx, "dependencies are ready")
+		c.setStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		slog.WarnContext(ctx, "dependencies are not ready", slog.Any("error", err))
+		c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+func (c *Checker) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
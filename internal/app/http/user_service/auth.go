@@ -0,0 +1,89 @@
+package user_service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/auth"
+	"github.com/obsessed-gopher/micro-service-guide/internal/logger"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// authorize проверяет доступ к маршруту по правилу rule до вызова next.
+// В правилах Self и Extra запросом выступает *http.Request.
+func (s *Server) authorize(rule auth.Rule, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		caller, err := s.authorizer.Authorize(r.Context(), r.Header.Get("Authorization"), rule, r)
+		if err != nil {
+			if !errors.Is(err, types.ErrUnauthenticated) && !errors.Is(err, types.ErrInvalidToken) &&
+				!errors.Is(err, types.ErrPermissionDenied) {
+				slog.ErrorContext(r.Context(), "authorization failed", slog.Any("error", err))
+			}
+
+			writeError(w, err)
+			return
+		}
+
+		if caller != nil {
+			ctx := auth.WithCaller(r.Context(), caller)
+			ctx = logger.WithAttrs(ctx, slog.String("caller_id", caller.UserID))
+			r = r.WithContext(ctx)
+		}
+
+		next(w, r)
+	}
+}
+
+// selfByPath проверяет, что {id} в пути совпадает с вызывающим.
+func selfByPath(caller *auth.Caller, req any) bool {
+	r, ok := req.(*http.Request)
+	return ok && r.PathValue("id") == caller.UserID
+}
+
+// updateUserPermission требует права на изменение пользователя для смены
+// email и имени. Смена одного статуса проверяется только updateUserExtra,
+// чтобы блокировать пользователей могла роль без права на изменение.
+func updateUserPermission(req any) types.Permission {
+	fields, ok := peekUpdateUser(req)
+	if ok && fields.Status != nil && fields.Email == nil && fields.Name == nil {
+		return ""
+	}
+
+	return types.PermissionUsersWrite
+}
+
+// updateUserExtra требует права на блокировку для смены статуса.
+// Тело, которое не удалось разобрать, считается сменой статуса.
+func updateUserExtra(req any) []types.Permission {
+	if fields, ok := peekUpdateUser(req); ok && fields.Status == nil {
+		return nil
+	}
+
+	return []types.Permission{types.PermissionUsersBlock}
+}
+
+// peekUpdateUser разбирает тело запроса на изменение пользователя
+// и подменяет его копией для хендлера. Возвращает false, если тело
+// не удалось разобрать.
+func peekUpdateUser(req any) (updateUserRequest, bool) {
+	r, ok := req.(*http.Request)
+	if !ok || r.Body == nil {
+		return updateUserRequest{}, true
+	}
+
+	// Читаем на байт больше лимита, чтобы хендлер по-прежнему
+	// отклонял слишком большое тело.
+	body, _ := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var fields updateUserRequest
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return updateUserRequest{}, false
+	}
+
+	return fields, true
+}
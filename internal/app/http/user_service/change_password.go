@@ -0,0 +1,44 @@
+package user_service
+
+import (
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+type changePasswordRequest struct {
+	OldPassword    string `json:"old_password"`
+	NewPassword    string `json:"new_password"`
+	RevokeSessions bool   `json:"revoke_sessions"`
+}
+
+// ChangePassword меняет пароль пользователя после проверки текущего.
+// POST /v1/users/{id}/password
+func (s *Server) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var req changePasswordRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.OldPassword == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "old_password is required")
+		return
+	}
+	if req.NewPassword == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "new_password is required")
+		return
+	}
+
+	user, err := s.userUsecase.ChangePassword(r.Context(), r.PathValue("id"), models.ChangePasswordInput{
+		OldPassword:    req.OldPassword,
+		NewPassword:    req.NewPassword,
+		RevokeSessions: req.RevokeSessions,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userToJSON(user))
+}
@@ -0,0 +1,42 @@
+package user_service
+
+import (
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+type confirmPasswordResetRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// ConfirmPasswordReset устанавливает новый пароль по токену сброса.
+// POST /v1/password-reset/confirm
+func (s *Server) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req confirmPasswordResetRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Token == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "token is required")
+		return
+	}
+	if req.NewPassword == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "new_password is required")
+		return
+	}
+
+	err := s.userUsecase.ConfirmPasswordReset(r.Context(), models.ConfirmPasswordResetInput{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,34 @@
+package user_service
+
+import "net/http"
+
+type totpCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type confirmTOTPResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ConfirmTOTP включает MFA по первому коду и возвращает коды восстановления.
+// POST /v1/users/{id}/mfa/totp/confirm
+func (s *Server) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	var req totpCodeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Code == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	codes, err := s.userUsecase.ConfirmTOTP(r.Context(), r.PathValue("id"), req.Code)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, confirmTOTPResponse{RecoveryCodes: codes})
+}
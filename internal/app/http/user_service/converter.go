@@ -0,0 +1,64 @@
+package user_service
+
+import (
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// userJSON - представление пользователя в JSON.
+type userJSON struct {
+	ID                string `json:"id"`
+	Email             string `json:"email"`
+	Name              string `json:"name"`
+	Status            string `json:"status"`
+	CreatedAt         int64  `json:"created_at"`
+	UpdatedAt         int64  `json:"updated_at"`
+	Version           int64  `json:"version"`
+	DeletedAt         *int64 `json:"deleted_at,omitempty"`
+	PasswordChangedAt int64  `json:"password_changed_at"`
+	EmailVerifiedAt   *int64 `json:"email_verified_at,omitempty"`
+	MFAEnabled        bool   `json:"mfa_enabled"`
+	TenantID          string `json:"tenant_id"`
+}
+
+// userToJSON конвертирует бизнес-модель в JSON представление.
+func userToJSON(u *models.User) userJSON {
+	result := userJSON{
+		ID:                u.ID,
+		Email:             u.Email,
+		Name:              u.Name,
+		Status:            u.EffectiveStatus().String(),
+		CreatedAt:         u.CreatedAt.Unix(),
+		UpdatedAt:         u.UpdatedAt.Unix(),
+		Version:           u.Version,
+		PasswordChangedAt: u.PasswordChangedAt.Unix(),
+		MFAEnabled:        u.IsMFAEnabled(),
+		TenantID:          u.TenantID,
+	}
+
+	if u.DeletedAt != nil {
+		deletedAt := u.DeletedAt.Unix()
+		result.DeletedAt = &deletedAt
+	}
+
+	if u.EmailVerifiedAt != nil {
+		verifiedAt := u.EmailVerifiedAt.Unix()
+		result.EmailVerifiedAt = &verifiedAt
+	}
+
+	return result
+}
+
+// statusFromString конвертирует строковый статус во внутренний.
+func statusFromString(s string) types.UserStatus {
+	switch s {
+	case types.UserStatusActive.String():
+		return types.UserStatusActive
+	case types.UserStatusInactive.String():
+		return types.UserStatusInactive
+	case types.UserStatusBlocked.String():
+		return types.UserStatusBlocked
+	default:
+		return types.UserStatusUnspecified
+	}
+}
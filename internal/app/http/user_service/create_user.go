@@ -0,0 +1,44 @@
+package user_service
+
+import (
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+type createUserRequest struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// CreateUser создаёт нового пользователя.
+// POST /v1/users
+func (s *Server) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req createUserRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Email == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "email is required")
+		return
+	}
+	if req.Password == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "password is required")
+		return
+	}
+
+	user, err := s.userUsecase.Create(r.Context(), models.CreateUserInput{
+		Email:    req.Email,
+		Name:     req.Name,
+		Password: req.Password,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, userToJSON(user))
+}
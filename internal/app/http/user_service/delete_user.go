@@ -0,0 +1,27 @@
+package user_service
+
+import (
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// DeleteUser удаляет пользователя.
+// DELETE /v1/users/{id}
+func (s *Server) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	filter := models.UserFilter{IDs: []string{r.PathValue("id")}}
+
+	count, err := s.userUsecase.Delete(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if count == 0 {
+		writeError(w, types.ErrUserNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
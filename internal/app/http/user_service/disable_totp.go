@@ -0,0 +1,25 @@
+package user_service
+
+import "net/http"
+
+// DisableTOTP выключает MFA по действующему коду или коду восстановления.
+// POST /v1/users/{id}/mfa/totp/disable
+func (s *Server) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	var req totpCodeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Code == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	if err := s.userUsecase.DisableTOTP(r.Context(), r.PathValue("id"), req.Code); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,23 @@
+package user_service
+
+import "net/http"
+
+type enrollTOTPResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// EnrollTOTP выпускает секрет TOTP для подключения приложения-аутентификатора.
+// POST /v1/users/{id}/mfa/totp
+func (s *Server) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	enrollment, err := s.userUsecase.EnrollTOTP(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, enrollTOTPResponse{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+	})
+}
@@ -0,0 +1,89 @@
+package user_service
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// mapError конвертирует бизнес-ошибки в HTTP статусы.
+// Соответствует mapError gRPC сервера с учётом стандартного маппинга gRPC кодов в HTTP.
+func mapError(err error) (int, string) {
+	switch {
+	case errors.Is(err, types.ErrUserNotFound):
+		return http.StatusNotFound, types.ErrUserNotFound.Error()
+	case errors.Is(err, types.ErrSessionNotFound):
+		return http.StatusNotFound, types.ErrSessionNotFound.Error()
+	case errors.Is(err, types.ErrOrganizationNotFound), errors.Is(err, types.ErrMemberNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, types.ErrMemberAlreadyExists):
+		return http.StatusConflict, types.ErrMemberAlreadyExists.Error()
+	case errors.Is(err, types.ErrUserAlreadyExists):
+		return http.StatusConflict, types.ErrUserAlreadyExists.Error()
+	case errors.Is(err, types.ErrVersionConflict):
+		return http.StatusConflict, types.ErrVersionConflict.Error()
+	case errors.Is(err, types.ErrInvalidEmail), errors.Is(err, types.ErrInvalidPassword),
+		errors.Is(err, types.ErrInvalidPageToken), errors.Is(err, types.ErrInvalidOrderBy),
+		errors.Is(err, types.ErrEmptySearchQuery), errors.Is(err, types.ErrWrongPassword),
+		errors.Is(err, types.ErrInvalidMFACode), errors.Is(err, types.ErrInvalidRole),
+		errors.Is(err, types.ErrTenantRequired), errors.Is(err, types.ErrInvalidTenant),
+		errors.Is(err, types.ErrInvalidOrganizationName), errors.Is(err, types.ErrInvalidMemberRole),
+		errors.Is(err, types.ErrInvalidStatus):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, types.ErrUserBlocked), errors.Is(err, types.ErrUserInactive),
+		errors.Is(err, types.ErrMFAAlreadyEnabled), errors.Is(err, types.ErrMFANotEnabled):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, types.ErrInvalidCredentials), errors.Is(err, types.ErrInvalidToken),
+		errors.Is(err, types.ErrUnauthenticated):
+		return http.StatusUnauthorized, err.Error()
+	case errors.Is(err, types.ErrPermissionDenied):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, types.ErrTooManyAttempts):
+		return http.StatusTooManyRequests, err.Error()
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
+
+// writeError отправляет ответ с бизнес-ошибкой.
+// Нарушения политики паролей дополнительно передаются списком violations,
+// для ограничения попыток входа выставляется заголовок Retry-After.
+func writeError(w http.ResponseWriter, err error) {
+	code, message := mapError(err)
+
+	var throttledErr *types.LoginThrottledError
+	if errors.As(err, &throttledErr) {
+		w.Header().Set("Retry-After", strconv.Itoa(throttledErr.RetryAfterSeconds()))
+	}
+
+	var policyErr *types.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		resp := passwordPolicyErrorJSON{Error: message}
+		for _, v := range policyErr.Violations {
+			resp.Violations = append(resp.Violations, passwordViolationJSON{Reason: v.Reason, Message: v.Message})
+		}
+
+		writeJSON(w, code, resp)
+		return
+	}
+
+	writeErrorMessage(w, code, message)
+}
+
+// passwordPolicyErrorJSON - ответ с нарушениями политики паролей.
+type passwordPolicyErrorJSON struct {
+	Error      string                  `json:"error"`
+	Violations []passwordViolationJSON `json:"violations"`
+}
+
+type passwordViolationJSON struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// writeErrorMessage отправляет ответ с ошибкой в формате {"error": "..."}.
+func writeErrorMessage(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"error": message})
+}
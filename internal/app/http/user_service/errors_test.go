@@ -0,0 +1,95 @@
+package user_service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+func TestWriteError_StatusCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"user not found", fmt.Errorf("get user: %w", types.ErrUserNotFound), http.StatusNotFound},
+		{"organization not found", types.ErrOrganizationNotFound, http.StatusNotFound},
+		{"already exists", types.ErrUserAlreadyExists, http.StatusConflict},
+		{"version conflict", types.ErrVersionConflict, http.StatusConflict},
+		{"invalid email", types.ErrInvalidEmail, http.StatusBadRequest},
+		{"invalid status", types.ErrInvalidStatus, http.StatusBadRequest},
+		{"user blocked", types.ErrUserBlocked, http.StatusBadRequest},
+		{"invalid credentials", types.ErrInvalidCredentials, http.StatusUnauthorized},
+		{"unauthenticated", types.ErrUnauthenticated, http.StatusUnauthorized},
+		{"permission denied", types.ErrPermissionDenied, http.StatusForbidden},
+		{"too many attempts", types.ErrTooManyAttempts, http.StatusTooManyRequests},
+		{"unknown error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeError(rec, tt.err)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("writeError() code = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			var body map[string]any
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || body["error"] == "" {
+				t.Errorf("writeError() body = %v, %v, want error message", body, err)
+			}
+		})
+	}
+}
+
+func TestWriteError_InternalMessageHidden(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeError(rec, errors.New("pq: password authentication failed"))
+
+	var body map[string]string
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+
+	if body["error"] != "internal error" {
+		t.Errorf("writeError() message = %q, want %q", body["error"], "internal error")
+	}
+}
+
+func TestWriteError_RetryAfter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeError(rec, &types.LoginThrottledError{RetryAfter: 90 * time.Second})
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("writeError() code = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+
+	if got := rec.Header().Get("Retry-After"); got != "90" {
+		t.Errorf("writeError() Retry-After = %q, want %q", got, "90")
+	}
+}
+
+func TestWriteError_PasswordViolations(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeError(rec, &types.PasswordPolicyError{Violations: []types.PasswordViolation{
+		{Reason: types.PasswordTooShort, Message: "must be at least 12 characters long"},
+	}})
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("writeError() code = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	var body passwordPolicyErrorJSON
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+
+	if len(body.Violations) != 1 || body.Violations[0].Reason != types.PasswordTooShort {
+		t.Errorf("writeError() violations = %+v, want %s", body.Violations, types.PasswordTooShort)
+	}
+}
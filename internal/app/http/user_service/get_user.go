@@ -0,0 +1,17 @@
+package user_service
+
+import (
+	"net/http"
+)
+
+// GetUser возвращает пользователя по ID.
+// GET /v1/users/{id}
+func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
+	user, err := s.userUsecase.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userToJSON(user))
+}
@@ -0,0 +1,39 @@
+package user_service
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// maxBodySize - максимальный размер тела запроса.
+const maxBodySize = 1 << 20
+
+// errTrailingData - после JSON объекта в теле есть лишние данные.
+var errTrailingData = errors.New("unexpected data after JSON object")
+
+// decodeJSON читает тело запроса в v. Тело должно содержать
+// ровно один JSON объект.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return errTrailingData
+	}
+
+	return nil
+}
+
+// writeJSON отправляет v в формате JSON.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
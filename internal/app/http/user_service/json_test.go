@@ -0,0 +1,68 @@
+package user_service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"single object", `{"name": "Alice"}`, false},
+		{"trailing whitespace", "{\"name\": \"Alice\"}\n", false},
+		{"trailing object", `{"name": "Alice"}{"status": "blocked"}`, true},
+		{"trailing garbage", `{"name": "Alice"} x`, true},
+		{"unknown field", `{"nickname": "Alice"}`, true},
+		{"empty body", ``, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			var req updateUserRequest
+			err := decodeJSON(httptest.NewRecorder(), r, &req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// updateUserUsecase запоминает, вызывался ли Update.
+type updateUserUsecase struct {
+	UserUsecase
+	called bool
+}
+
+func (m *updateUserUsecase) Update(ctx context.Context, id string, input models.UpdateUserInput) (*models.User, error) {
+	m.called = true
+	return &models.User{ID: id}, nil
+}
+
+func TestServer_UpdateUser_TrailingData(t *testing.T) {
+	usecase := &updateUserUsecase{}
+	server := &Server{userUsecase: usecase}
+
+	r := httptest.NewRequest(http.MethodPatch, "/v1/users/user-id", strings.NewReader(`{"name": "Alice"}{"status": "blocked"}`))
+	r.SetPathValue("id", "user-id")
+	rec := httptest.NewRecorder()
+
+	server.UpdateUser(rec, r)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("UpdateUser() code = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	if usecase.called {
+		t.Error("UpdateUser() applied a body with trailing data")
+	}
+}
@@ -0,0 +1,202 @@
+package user_service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
+)
+
+type listUsersResponse struct {
+	Users         []userJSON `json:"users"`
+	Total         int        `json:"total"`
+	NextPageToken string     `json:"next_page_token,omitempty"`
+}
+
+// ListUsers возвращает список пользователей.
+// GET /v1/users?id=...&email=...&status=active&include_deleted=true&order_by=email,created_at+desc&limit=20&page_token=...
+// Фильтры описаны в userFilterFromQuery.
+func (s *Server) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, err := intParam(query.Get("limit"))
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	offset, err := intParam(query.Get("offset"))
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	userFilter, err := userFilterFromQuery(query)
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := usecases.ListFilter{
+		Filters:   userFilter,
+		Limit:     limit,
+		Offset:    offset,
+		PageToken: query.Get("page_token"),
+		OrderBy:   query.Get("order_by"),
+	}
+
+	result, err := s.userUsecase.List(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := listUsersResponse{
+		Users:         make([]userJSON, len(result.Users)),
+		Total:         result.Total,
+		NextPageToken: result.NextPageToken,
+	}
+	for i, u := range result.Users {
+		resp.Users[i] = userToJSON(u)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// userFilterFromQuery разбирает фильтр пользователей из query параметров:
+//
+//	id, email, status, exclude_status, email_domain - повторяемые, точное совпадение
+//	email_prefix, email_contains, name_prefix, name_contains - без учёта регистра
+//	created_from, created_to, updated_from, updated_to - unix секунды, интервал [from, to)
+//	include_deleted - true, чтобы вернуть удалённых
+//
+// Текст ошибки предназначен для клиента.
+func userFilterFromQuery(query url.Values) (models.UserFilter, error) {
+	filter := models.UserFilter{
+		IDs:          query["id"],
+		Emails:       query["email"],
+		EmailDomains: query["email_domain"],
+	}
+
+	var err error
+
+	if filter.Statuses, err = statusesParam(query["status"]); err != nil {
+		return filter, errors.New("invalid status")
+	}
+
+	if filter.ExcludeStatuses, err = statusesParam(query["exclude_status"]); err != nil {
+		return filter, errors.New("invalid exclude_status")
+	}
+
+	if filter.EmailMatch, err = stringMatchParam(query, "email"); err != nil {
+		return filter, err
+	}
+
+	if filter.NameMatch, err = stringMatchParam(query, "name"); err != nil {
+		return filter, err
+	}
+
+	if filter.CreatedAt, err = timeRangeParam(query, "created"); err != nil {
+		return filter, err
+	}
+
+	if filter.UpdatedAt, err = timeRangeParam(query, "updated"); err != nil {
+		return filter, err
+	}
+
+	if raw := query.Get("include_deleted"); raw != "" {
+		if filter.IncludeDeleted, err = strconv.ParseBool(raw); err != nil {
+			return filter, errors.New("invalid include_deleted")
+		}
+	}
+
+	return filter, nil
+}
+
+// statusesParam парсит повторяемый параметр статусов.
+func statusesParam(raw []string) ([]types.UserStatus, error) {
+	var statuses []types.UserStatus
+
+	for _, s := range raw {
+		st := statusFromString(s)
+		if !st.IsValid() {
+			return nil, fmt.Errorf("invalid status %q", s)
+		}
+		statuses = append(statuses, st)
+	}
+
+	return statuses, nil
+}
+
+// stringMatchParam парсит пару параметров <name>_prefix / <name>_contains.
+func stringMatchParam(query url.Values, name string) (models.StringMatch, error) {
+	prefix, contains := query.Get(name+"_prefix"), query.Get(name+"_contains")
+
+	switch {
+	case prefix != "" && contains != "":
+		return models.StringMatch{}, fmt.Errorf("%s_prefix and %s_contains are mutually exclusive", name, name)
+	case prefix != "":
+		return models.StringMatch{Value: prefix, Prefix: true}, nil
+	default:
+		return models.StringMatch{Value: contains}, nil
+	}
+}
+
+// timeRangeParam парсит пару параметров <name>_from / <name>_to.
+func timeRangeParam(query url.Values, name string) (models.TimeRange, error) {
+	from, err := unixParam(query, name+"_from")
+	if err != nil {
+		return models.TimeRange{}, err
+	}
+
+	to, err := unixParam(query, name+"_to")
+	if err != nil {
+		return models.TimeRange{}, err
+	}
+
+	return models.TimeRange{From: from, To: to}, nil
+}
+
+// unixParam парсит необязательный параметр времени в unix секундах.
+func unixParam(query url.Values, param string) (*time.Time, error) {
+	raw := query.Get(param)
+	if raw == "" {
+		return nil, nil
+	}
+
+	sec, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", param)
+	}
+
+	t := time.Unix(sec, 0)
+
+	return &t, nil
+}
+
+// errNegativeParam - отрицательное значение параметра limit или offset.
+var errNegativeParam = errors.New("negative value")
+
+// intParam парсит необязательный неотрицательный целочисленный query параметр.
+func intParam(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	if v < 0 {
+		return 0, errNegativeParam
+	}
+
+	return v, nil
+}
@@ -0,0 +1,112 @@
+package user_service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
+)
+
+// listUsersUsecase запоминает фильтр последнего вызова List.
+type listUsersUsecase struct {
+	UserUsecase
+	filter *usecases.ListFilter
+}
+
+func (m *listUsersUsecase) List(ctx context.Context, filter usecases.ListFilter) (*usecases.ListResult, error) {
+	m.filter = &filter
+	return &usecases.ListResult{}, nil
+}
+
+func TestServer_ListUsers_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		wantMessage string
+	}{
+		{"non-numeric limit", "limit=ten", "invalid limit"},
+		{"negative limit", "limit=-1", "invalid limit"},
+		{"non-numeric offset", "offset=x", "invalid offset"},
+		{"negative offset", "offset=-5", "invalid offset"},
+		{"unknown status", "status=deleted", "invalid status"},
+		{"unknown exclude_status", "exclude_status=unknown", "invalid exclude_status"},
+		{"prefix and contains", "email_prefix=a&email_contains=b", "email_prefix and email_contains are mutually exclusive"},
+		{"invalid time", "created_from=yesterday", "invalid created_from"},
+		{"invalid include_deleted", "include_deleted=maybe", "invalid include_deleted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := &listUsersUsecase{}
+			server := &Server{userUsecase: usecase}
+
+			rec := httptest.NewRecorder()
+			server.ListUsers(rec, httptest.NewRequest(http.MethodGet, "/v1/users?"+tt.query, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("ListUsers() code = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+
+			var body map[string]string
+			_ = json.NewDecoder(rec.Body).Decode(&body)
+
+			if body["error"] != tt.wantMessage {
+				t.Errorf("ListUsers() error = %q, want %q", body["error"], tt.wantMessage)
+			}
+
+			if usecase.filter != nil {
+				t.Error("ListUsers() called usecase with invalid query")
+			}
+		})
+	}
+}
+
+func TestServer_ListUsers_Query(t *testing.T) {
+	usecase := &listUsersUsecase{}
+	server := &Server{userUsecase: usecase}
+
+	query := "limit=10&offset=20&status=active&status=blocked&exclude_status=inactive" +
+		"&email_domain=acme.com&name_prefix=Al&created_from=1700000000&include_deleted=true" +
+		"&order_by=email&page_token=token"
+
+	rec := httptest.NewRecorder()
+	server.ListUsers(rec, httptest.NewRequest(http.MethodGet, "/v1/users?"+query, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ListUsers() code = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	f := usecase.filter
+	if f.Limit != 10 || f.Offset != 20 || f.OrderBy != "email" || f.PageToken != "token" {
+		t.Errorf("ListUsers() pagination = %+v", f)
+	}
+
+	if !slices.Equal(f.Filters.Statuses, []types.UserStatus{types.UserStatusActive, types.UserStatusBlocked}) {
+		t.Errorf("ListUsers() statuses = %v", f.Filters.Statuses)
+	}
+
+	if !slices.Equal(f.Filters.ExcludeStatuses, []types.UserStatus{types.UserStatusInactive}) {
+		t.Errorf("ListUsers() exclude statuses = %v", f.Filters.ExcludeStatuses)
+	}
+
+	if !slices.Equal(f.Filters.EmailDomains, []string{"acme.com"}) {
+		t.Errorf("ListUsers() email domains = %v", f.Filters.EmailDomains)
+	}
+
+	if f.Filters.NameMatch.Value != "Al" || !f.Filters.NameMatch.Prefix {
+		t.Errorf("ListUsers() name match = %+v, want prefix Al", f.Filters.NameMatch)
+	}
+
+	if f.Filters.CreatedAt.From == nil || f.Filters.CreatedAt.From.Unix() != 1700000000 || f.Filters.CreatedAt.To != nil {
+		t.Errorf("ListUsers() created range = %+v", f.Filters.CreatedAt)
+	}
+
+	if !f.Filters.IncludeDeleted {
+		t.Error("ListUsers() include_deleted = false, want true")
+	}
+}
@@ -0,0 +1,230 @@
+package user_service
+
+import (
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
+)
+
+// organizationJSON - представление организации в JSON.
+type organizationJSON struct {
+	ID        string `json:"id"`
+	TenantID  string `json:"tenant_id"`
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// memberJSON - представление участника организации в JSON.
+type memberJSON struct {
+	OrganizationID string `json:"organization_id"`
+	UserID         string `json:"user_id"`
+	Role           string `json:"role"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+// userOrganizationJSON - организация пользователя и его роль в ней.
+type userOrganizationJSON struct {
+	Organization organizationJSON `json:"organization"`
+	Role         string           `json:"role"`
+	JoinedAt     int64            `json:"joined_at"`
+}
+
+func organizationToJSON(o *models.Organization) organizationJSON {
+	return organizationJSON{
+		ID:        o.ID,
+		TenantID:  o.TenantID,
+		Name:      o.Name,
+		CreatedAt: o.CreatedAt.Unix(),
+		UpdatedAt: o.UpdatedAt.Unix(),
+	}
+}
+
+func memberToJSON(m *models.Membership) memberJSON {
+	return memberJSON{
+		OrganizationID: m.OrganizationID,
+		UserID:         m.UserID,
+		Role:           string(m.Role),
+		CreatedAt:      m.CreatedAt.Unix(),
+	}
+}
+
+type createOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateOrganization создаёт организацию.
+// POST /v1/organizations
+func (s *Server) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req createOrganizationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Name == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	org, err := s.orgUsecase.Create(r.Context(), models.CreateOrganizationInput{Name: req.Name})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, organizationToJSON(org))
+}
+
+// GetOrganization возвращает организацию по ID.
+// GET /v1/organizations/{id}
+func (s *Server) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	org, err := s.orgUsecase.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, organizationToJSON(org))
+}
+
+type listOrganizationsResponse struct {
+	Organizations []organizationJSON `json:"organizations"`
+	Total         int                `json:"total"`
+}
+
+// ListOrganizations возвращает список организаций, упорядоченных по названию.
+// GET /v1/organizations?limit=20&offset=0
+func (s *Server) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, err := intParam(query.Get("limit"))
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	offset, err := intParam(query.Get("offset"))
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	result, err := s.orgUsecase.List(r.Context(), usecases.OrganizationListFilter{Limit: limit, Offset: offset})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := listOrganizationsResponse{
+		Organizations: make([]organizationJSON, len(result.Organizations)),
+		Total:         result.Total,
+	}
+	for i, org := range result.Organizations {
+		resp.Organizations[i] = organizationToJSON(org)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// DeleteOrganization удаляет организацию вместе с членством в ней.
+// DELETE /v1/organizations/{id}
+func (s *Server) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	if err := s.orgUsecase.Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type addOrganizationMemberRequest struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// AddOrganizationMember добавляет пользователя в организацию.
+// POST /v1/organizations/{id}/members
+func (s *Server) AddOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	var req addOrganizationMemberRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.UserID == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	member, err := s.orgUsecase.AddMember(r.Context(), models.AddMemberInput{
+		OrganizationID: r.PathValue("id"),
+		UserID:         req.UserID,
+		Role:           types.MemberRole(req.Role),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, memberToJSON(member))
+}
+
+// RemoveOrganizationMember исключает пользователя из организации.
+// DELETE /v1/organizations/{id}/members/{user_id}
+func (s *Server) RemoveOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	if err := s.orgUsecase.RemoveMember(r.Context(), r.PathValue("id"), r.PathValue("user_id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type listOrganizationMembersResponse struct {
+	Members []memberJSON `json:"members"`
+}
+
+// ListOrganizationMembers возвращает участников организации.
+// GET /v1/organizations/{id}/members
+func (s *Server) ListOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	members, err := s.orgUsecase.ListMembers(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := listOrganizationMembersResponse{Members: make([]memberJSON, len(members))}
+	for i, member := range members {
+		resp.Members[i] = memberToJSON(member)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type listUserOrganizationsResponse struct {
+	Organizations []userOrganizationJSON `json:"organizations"`
+}
+
+// ListUserOrganizations возвращает организации пользователя и его роли в них.
+// GET /v1/users/{id}/organizations
+func (s *Server) ListUserOrganizations(w http.ResponseWriter, r *http.Request) {
+	orgs, err := s.orgUsecase.ListUserOrganizations(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := listUserOrganizationsResponse{Organizations: make([]userOrganizationJSON, len(orgs))}
+	for i, org := range orgs {
+		resp.Organizations[i] = userOrganizationJSON{
+			Organization: organizationToJSON(org.Organization),
+			Role:         string(org.Role),
+			JoinedAt:     org.JoinedAt.Unix(),
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,32 @@
+package user_service
+
+import (
+	"net/http"
+)
+
+type requestPasswordResetRequest struct {
+	Email string `json:"email"`
+}
+
+// RequestPasswordReset отправляет пользователю токен сброса пароля.
+// Ответ не зависит от того, существует ли email.
+// POST /v1/password-reset
+func (s *Server) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req requestPasswordResetRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Email == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "email is required")
+		return
+	}
+
+	if err := s.userUsecase.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
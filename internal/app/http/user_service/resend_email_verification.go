@@ -0,0 +1,32 @@
+package user_service
+
+import (
+	"net/http"
+)
+
+type resendEmailVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// ResendEmailVerification повторно отправляет токен подтверждения email.
+// Ответ не зависит от того, существует ли email.
+// POST /v1/email-verification/resend
+func (s *Server) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	var req resendEmailVerificationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Email == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "email is required")
+		return
+	}
+
+	if err := s.userUsecase.ResendEmailVerification(r.Context(), req.Email); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
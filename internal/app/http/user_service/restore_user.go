@@ -0,0 +1,17 @@
+package user_service
+
+import (
+	"net/http"
+)
+
+// RestoreUser восстанавливает мягко удалённого пользователя.
+// POST /v1/users/{id}/restore
+func (s *Server) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	user, err := s.userUsecase.Restore(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userToJSON(user))
+}
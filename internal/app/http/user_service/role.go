@@ -0,0 +1,58 @@
+package user_service
+
+import (
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+type assignRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type rolesResponse struct {
+	Roles []types.Role `json:"roles"`
+}
+
+// AssignRole выдаёт пользователю роль.
+// POST /v1/users/{id}/roles
+func (s *Server) AssignRole(w http.ResponseWriter, r *http.Request) {
+	var req assignRoleRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Role == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "role is required")
+		return
+	}
+
+	roles, err := s.userUsecase.AssignRole(r.Context(), r.PathValue("id"), types.Role(req.Role))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rolesResponse{Roles: nonNilRoles(roles)})
+}
+
+// RevokeRole отзывает роль пользователя.
+// DELETE /v1/users/{id}/roles/{role}
+func (s *Server) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	roles, err := s.userUsecase.RevokeRole(r.Context(), r.PathValue("id"), types.Role(r.PathValue("role")))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rolesResponse{Roles: nonNilRoles(roles)})
+}
+
+// nonNilRoles заменяет nil пустым списком, чтобы в JSON был [], а не null.
+func nonNilRoles(roles []types.Role) []types.Role {
+	if roles == nil {
+		return []types.Role{}
+	}
+	return roles
+}
@@ -0,0 +1,52 @@
+package user_service
+
+import (
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
+)
+
+// SearchUsers ищет пользователей по имени и email, самые релевантные первыми.
+// GET /v1/users/search?q=...&limit=20&offset=0 плюс фильтры ListUsers.
+func (s *Server) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, err := intParam(query.Get("limit"))
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	offset, err := intParam(query.Get("offset"))
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	userFilter, err := userFilterFromQuery(query)
+	if err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := s.userUsecase.Search(r.Context(), usecases.SearchFilter{
+		Query:   query.Get("q"),
+		Filters: userFilter,
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := listUsersResponse{
+		Users: make([]userJSON, len(result.Users)),
+		Total: result.Total,
+	}
+	for i, u := range result.Users {
+		resp.Users[i] = userToJSON(u)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,132 @@
+// Package user_service содержит HTTP/JSON шлюз к сервису пользователей.
+package user_service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/app/auth"
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	"github.com/obsessed-gopher/micro-service-guide/internal/usecases"
+)
+
+// UserUsecase - интерфейс бизнес-логики пользователей.
+type UserUsecase interface {
+	Create(ctx context.Context, input models.CreateUserInput) (*models.User, error)
+	GetByID(ctx context.Context, id string) (*models.User, error)
+	Update(ctx context.Context, id string, input models.UpdateUserInput) (*models.User, error)
+	Delete(ctx context.Context, filter models.UserFilter) (int, error)
+	Restore(ctx context.Context, id string) (*models.User, error)
+	ChangePassword(ctx context.Context, id string, input models.ChangePasswordInput) (*models.User, error)
+	SetPassword(ctx context.Context, id string, input models.SetPasswordInput) (*models.User, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, input models.ConfirmPasswordResetInput) error
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+	ResendEmailVerification(ctx context.Context, email string) error
+	EnrollTOTP(ctx context.Context, userID string) (*models.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID, code string) error
+	UnlockUser(ctx context.Context, id string) (*models.User, error)
+	AssignRole(ctx context.Context, userID string, role types.Role) ([]types.Role, error)
+	RevokeRole(ctx context.Context, userID string, role types.Role) ([]types.Role, error)
+	List(ctx context.Context, filter usecases.ListFilter) (*usecases.ListResult, error)
+	Search(ctx context.Context, filter usecases.SearchFilter) (*usecases.ListResult, error)
+}
+
+// OrganizationUsecase - интерфейс бизнес-логики организаций.
+type OrganizationUsecase interface {
+	Create(ctx context.Context, input models.CreateOrganizationInput) (*models.Organization, error)
+	GetByID(ctx context.Context, id string) (*models.Organization, error)
+	List(ctx context.Context, filter usecases.OrganizationListFilter) (*usecases.OrganizationListResult, error)
+	Delete(ctx context.Context, id string) error
+	AddMember(ctx context.Context, input models.AddMemberInput) (*models.Membership, error)
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	ListMembers(ctx context.Context, orgID string) ([]*models.Membership, error)
+	ListUserOrganizations(ctx context.Context, userID string) ([]*models.UserOrganization, error)
+}
+
+// Server - HTTP сервер сервиса пользователей.
+type Server struct {
+	userUsecase   UserUsecase
+	orgUsecase    OrganizationUsecase
+	authorizer    *auth.Authorizer
+	defaultTenant string
+}
+
+// NewServer создаёт новый сервер. defaultTenant используется для запросов
+// без заголовка X-Tenant-ID, пустое значение делает заголовок обязательным.
+func NewServer(
+	userUsecase UserUsecase,
+	orgUsecase OrganizationUsecase,
+	authorizer *auth.Authorizer,
+	defaultTenant string,
+) *Server {
+	return &Server{
+		userUsecase:   userUsecase,
+		orgUsecase:    orgUsecase,
+		authorizer:    authorizer,
+		defaultTenant: defaultTenant,
+	}
+}
+
+// Handler возвращает роутер с REST эндпоинтами. Каждый запрос выполняется
+// в арендаторе из заголовка X-Tenant-ID, доступ к маршруту проверяется
+// по access токену из заголовка Authorization.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	public := auth.Rule{Public: true}
+	read := auth.Rule{Permission: types.PermissionUsersRead}
+	readSelf := auth.Rule{Permission: types.PermissionUsersRead, Self: selfByPath}
+	writeSelf := auth.Rule{Permission: types.PermissionUsersWrite, Self: selfByPath}
+	orgsRead := auth.Rule{Permission: types.PermissionOrgsRead}
+	orgsWrite := auth.Rule{Permission: types.PermissionOrgsWrite}
+
+	routes := []struct {
+		pattern string
+		rule    auth.Rule
+		handler http.HandlerFunc
+	}{
+		{"POST /v1/users", public, s.CreateUser},
+		{"GET /v1/users", read, s.ListUsers},
+		{"GET /v1/users/search", read, s.SearchUsers},
+		{"GET /v1/users/{id}", readSelf, s.GetUser},
+		{"PATCH /v1/users/{id}", auth.Rule{
+			PermissionFor: updateUserPermission,
+			Self:          selfByPath,
+			Extra:         updateUserExtra,
+		}, s.UpdateUser},
+		{"DELETE /v1/users/{id}", auth.Rule{Permission: types.PermissionUsersDelete}, s.DeleteUser},
+		{"POST /v1/users/{id}/restore", auth.Rule{Permission: types.PermissionUsersDelete}, s.RestoreUser},
+		{"POST /v1/users/{id}/password", writeSelf, s.ChangePassword},
+		{"PUT /v1/users/{id}/password", auth.Rule{Permission: types.PermissionUsersWrite}, s.SetPassword},
+		{"POST /v1/users/{id}/mfa/totp", writeSelf, s.EnrollTOTP},
+		{"POST /v1/users/{id}/mfa/totp/confirm", writeSelf, s.ConfirmTOTP},
+		{"POST /v1/users/{id}/mfa/totp/disable", writeSelf, s.DisableTOTP},
+		{"POST /v1/users/{id}/unlock", auth.Rule{Permission: types.PermissionUsersBlock}, s.UnlockUser},
+		{"POST /v1/users/{id}/roles", auth.Rule{Permission: types.PermissionUsersRoles}, s.AssignRole},
+		{"DELETE /v1/users/{id}/roles/{role}", auth.Rule{Permission: types.PermissionUsersRoles}, s.RevokeRole},
+		{"GET /v1/users/{id}/organizations", auth.Rule{
+			Permission: types.PermissionOrgsRead,
+			Self:       selfByPath,
+		}, s.ListUserOrganizations},
+		{"POST /v1/organizations", orgsWrite, s.CreateOrganization},
+		{"GET /v1/organizations", orgsRead, s.ListOrganizations},
+		{"GET /v1/organizations/{id}", orgsRead, s.GetOrganization},
+		{"DELETE /v1/organizations/{id}", orgsWrite, s.DeleteOrganization},
+		{"POST /v1/organizations/{id}/members", orgsWrite, s.AddOrganizationMember},
+		{"GET /v1/organizations/{id}/members", orgsRead, s.ListOrganizationMembers},
+		{"DELETE /v1/organizations/{id}/members/{user_id}", orgsWrite, s.RemoveOrganizationMember},
+		{"POST /v1/password-reset", public, s.RequestPasswordReset},
+		{"POST /v1/password-reset/confirm", public, s.ConfirmPasswordReset},
+		{"POST /v1/email-verification/confirm", public, s.VerifyEmail},
+		{"POST /v1/email-verification/resend", public, s.ResendEmailVerification},
+	}
+
+	for _, route := range routes {
+		mux.HandleFunc(route.pattern, s.withTenant(s.authorize(route.rule, route.handler)))
+	}
+
+	return mux
+}
@@ -0,0 +1,38 @@
+package user_service
+
+import (
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+type setPasswordRequest struct {
+	Password       string `json:"password"`
+	RevokeSessions bool   `json:"revoke_sessions"`
+}
+
+// SetPassword устанавливает пароль пользователя без проверки текущего (для администраторов).
+// PUT /v1/users/{id}/password
+func (s *Server) SetPassword(w http.ResponseWriter, r *http.Request) {
+	var req setPasswordRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Password == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "password is required")
+		return
+	}
+
+	user, err := s.userUsecase.SetPassword(r.Context(), r.PathValue("id"), models.SetPasswordInput{
+		Password:       req.Password,
+		RevokeSessions: req.RevokeSessions,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userToJSON(user))
+}
@@ -0,0 +1,26 @@
+package user_service
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/logger"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+)
+
+// withTenant определяет арендатора по заголовку X-Tenant-ID и кладёт
+// его в контекст запроса. Без заголовка используется арендатор по умолчанию.
+func (s *Server) withTenant(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantID, err := tenant.Resolve(r.Header.Get(tenant.Header), s.defaultTenant)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		ctx := tenant.WithID(r.Context(), tenantID)
+		ctx = logger.WithAttrs(ctx, slog.String("tenant_id", tenantID))
+
+		next(w, r.WithContext(ctx))
+	}
+}
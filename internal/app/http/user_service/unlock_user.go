@@ -0,0 +1,17 @@
+package user_service
+
+import (
+	"net/http"
+)
+
+// UnlockUser снимает задержки и блокировку входа после неудачных попыток.
+// POST /v1/users/{id}/unlock
+func (s *Server) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	user, err := s.userUsecase.UnlockUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userToJSON(user))
+}
@@ -0,0 +1,47 @@
+package user_service
+
+import (
+	"net/http"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+)
+
+type updateUserRequest struct {
+	Email  *string `json:"email"`
+	Name   *string `json:"name"`
+	Status *string `json:"status"`
+	// Version - ожидаемая версия пользователя; при несовпадении вернётся 409.
+	Version *int64 `json:"version"`
+}
+
+// UpdateUser частично обновляет данные пользователя.
+// PATCH /v1/users/{id}
+func (s *Server) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	var req updateUserRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	input := models.UpdateUserInput{
+		Email:           req.Email,
+		Name:            req.Name,
+		ExpectedVersion: req.Version,
+	}
+	if req.Status != nil {
+		st := statusFromString(*req.Status)
+		if !st.IsValid() {
+			writeErrorMessage(w, http.StatusBadRequest, "invalid status")
+			return
+		}
+		input.Status = &st
+	}
+
+	user, err := s.userUsecase.Update(r.Context(), r.PathValue("id"), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userToJSON(user))
+}
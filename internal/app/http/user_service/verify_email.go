@@ -0,0 +1,32 @@
+package user_service
+
+import (
+	"net/http"
+)
+
+type verifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// VerifyEmail подтверждает email пользователя по токену из письма.
+// POST /v1/email-verification/confirm
+func (s *Server) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req verifyEmailRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeErrorMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Token == "" {
+		writeErrorMessage(w, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	user, err := s.userUsecase.VerifyEmail(r.Context(), req.Token)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userToJSON(user))
+}
@@ -0,0 +1,60 @@
+// Package jobs содержит фоновые задачи сервиса.
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+)
+
+// UserPurger - интерфейс очистки мягко удалённых пользователей.
+type UserPurger interface {
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int, error)
+}
+
+// PurgeJob периодически физически удаляет пользователей,
+// мягко удалённых больше retention назад.
+type PurgeJob struct {
+	purger    UserPurger
+	retention time.Duration
+	interval  time.Duration
+}
+
+// Значение по умолчанию для незаданного интервала.
+const defaultPurgeInterval = time.Hour
+
+// NewPurgeJob создаёт задачу очистки удалённых пользователей.
+func NewPurgeJob(purger UserPurger, retention, interval time.Duration) *PurgeJob {
+	if interval <= 0 {
+		interval = defaultPurgeInterval
+	}
+
+	return &PurgeJob{
+		purger:    purger,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run выполняет очистку до отмены ctx во всех арендаторах.
+// Ошибки логируются, очистка повторяется на следующем тике.
+func (j *PurgeJob) Run(ctx context.Context) {
+	ctx = tenant.WithAll(ctx)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.purger.PurgeDeleted(ctx, j.retention); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to purge deleted users", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
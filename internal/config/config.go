@@ -11,11 +11,22 @@ import (
 
 // Config - корневая структура конфигурации.
 type Config struct {
-	App      AppConfig      `yaml:"app"`
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	Log      LogConfig      `yaml:"log"`
-	Metrics  MetricsConfig  `yaml:"metrics"`
+	App           AppConfig           `yaml:"app"`
+	Server        ServerConfig        `yaml:"server"`
+	Database      DatabaseConfig      `yaml:"database"`
+	Log           LogConfig           `yaml:"log"`
+	Metrics       MetricsConfig       `yaml:"metrics"`
+	Auth          AuthConfig          `yaml:"auth"`
+	Health        HealthConfig        `yaml:"health"`
+	Purge         PurgeConfig         `yaml:"purge"`
+	Email         EmailConfig         `yaml:"email"`
+	Password      PasswordConfig      `yaml:"password"`
+	PasswordHash  PasswordHashConfig  `yaml:"password_hash"`
+	PasswordReset PasswordResetConfig `yaml:"password_reset"`
+	Notifier      NotifierConfig      `yaml:"notifier"`
+	MFA           MFAConfig           `yaml:"mfa"`
+	LoginThrottle LoginThrottleConfig `yaml:"login_throttle"`
+	Tenancy       TenancyConfig       `yaml:"tenancy"`
 }
 
 // AppConfig - настройки приложения.
@@ -53,8 +64,15 @@ func (c HTTPConfig) Addr() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
+// Драйверы хранилища пользователей.
+const (
+	DriverMemory   = "memory"
+	DriverPostgres = "postgres"
+)
+
 // DatabaseConfig - настройки подключения к БД.
 type DatabaseConfig struct {
+	Driver          string        `yaml:"driver"`
 	Host            string        `yaml:"host"`
 	Port            int           `yaml:"port"`
 	Name            string        `yaml:"name"`
@@ -64,6 +82,8 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `yaml:"max_open_conns"`
 	MaxIdleConns    int           `yaml:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout"`
+	AutoMigrate     bool          `yaml:"auto_migrate"`
 }
 
 // DSN возвращает строку подключения к PostgreSQL.
@@ -86,6 +106,142 @@ type MetricsConfig struct {
 	Port    int  `yaml:"port"`
 }
 
+// Addr возвращает адрес HTTP сервера метрик.
+func (c MetricsConfig) Addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// AuthConfig - настройки аутентификации.
+type AuthConfig struct {
+	SigningKey      string        `yaml:"signing_key"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl"`
+}
+
+// HealthConfig - настройки проверки готовности.
+type HealthConfig struct {
+	CheckInterval time.Duration `yaml:"check_interval"`
+	CheckTimeout  time.Duration `yaml:"check_timeout"`
+}
+
+// PurgeConfig - настройки очистки мягко удалённых пользователей.
+type PurgeConfig struct {
+	Enabled   bool          `yaml:"enabled"`
+	Retention time.Duration `yaml:"retention"`
+	Interval  time.Duration `yaml:"interval"`
+}
+
+// EmailConfig - настройки канонизации и подтверждения email.
+// ProviderRules включает правила почтовых провайдеров (точки и +тег в Gmail).
+// RequireVerification создаёт новых пользователей неактивными до подтверждения email.
+// VerificationMaxRequests - лимит токенов на пользователя за VerificationWindow, 0 - без лимита.
+type EmailConfig struct {
+	ProviderRules           bool          `yaml:"provider_rules"`
+	RequireVerification     bool          `yaml:"require_verification"`
+	VerificationTTL         time.Duration `yaml:"verification_ttl"`
+	VerificationMaxRequests int           `yaml:"verification_max_requests"`
+	VerificationWindow      time.Duration `yaml:"verification_window"`
+}
+
+// PasswordConfig - настройки политики паролей.
+// MaxLength задаётся в байтах UTF-8, MinLength - в символах.
+// BreachedList - путь к файлу с распространёнными и утёкшими паролями,
+// по одному на строку; пустое значение отключает проверку.
+type PasswordConfig struct {
+	MinLength          int    `yaml:"min_length"`
+	MaxLength          int    `yaml:"max_length"`
+	RequireLowercase   bool   `yaml:"require_lowercase"`
+	RequireUppercase   bool   `yaml:"require_uppercase"`
+	RequireDigit       bool   `yaml:"require_digit"`
+	RequireSymbol      bool   `yaml:"require_symbol"`
+	ForbidPersonalInfo bool   `yaml:"forbid_personal_info"`
+	BreachedList       string `yaml:"breached_list"`
+}
+
+// Алгоритмы хэширования паролей.
+const (
+	HashArgon2id = "argon2id"
+	HashBcrypt   = "bcrypt"
+)
+
+// PasswordHashConfig - настройки хэширования паролей.
+// Algorithm - алгоритм для новых хэшей; хэши другого алгоритма или
+// с другими параметрами пересчитываются при входе пользователя.
+type PasswordHashConfig struct {
+	Algorithm  string       `yaml:"algorithm"`
+	BcryptCost int          `yaml:"bcrypt_cost"`
+	Argon2     Argon2Config `yaml:"argon2"`
+}
+
+// Argon2Config - параметры argon2id. Memory задаётся в КиБ,
+// нулевые значения заменяются значениями по умолчанию.
+type Argon2Config struct {
+	Memory      uint32 `yaml:"memory"`
+	Iterations  uint32 `yaml:"iterations"`
+	Parallelism uint8  `yaml:"parallelism"`
+}
+
+// PasswordResetConfig - настройки сброса пароля.
+// MaxRequests - лимит токенов на пользователя за Window, 0 - без лимита.
+type PasswordResetConfig struct {
+	TokenTTL    time.Duration `yaml:"token_ttl"`
+	MaxRequests int           `yaml:"max_requests"`
+	Window      time.Duration `yaml:"window"`
+}
+
+// Драйверы доставки уведомлений.
+const (
+	NotifierLog  = "log"
+	NotifierFile = "file"
+)
+
+// NotifierConfig - настройки доставки уведомлений пользователям.
+type NotifierConfig struct {
+	Driver string `yaml:"driver"`
+	File   string `yaml:"file"`
+}
+
+// MFAConfig - настройки двухфакторной аутентификации.
+// EncryptionKey - ключ AES-256 в base64 для шифрования секретов TOTP.
+// MaxAttempts - неверных кодов на один вход, RecoveryCodes - сколько
+// кодов восстановления выдаётся при подключении.
+type MFAConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	Issuer        string        `yaml:"issuer"`
+	EncryptionKey string        `yaml:"encryption_key"`
+	ChallengeTTL  time.Duration `yaml:"challenge_ttl"`
+	MaxAttempts   int           `yaml:"max_attempts"`
+	RecoveryCodes int           `yaml:"recovery_codes"`
+}
+
+// LoginThrottleConfig - настройки защиты от перебора паролей.
+// После free_attempts ошибок задержка растёт от BaseDelay вдвое с каждой
+// ошибкой до MaxDelay, после lock_after ошибок вход блокируется на
+// LockDuration. Счётчики сбрасываются, если ошибок не было дольше Window.
+type LoginThrottleConfig struct {
+	Enabled      bool              `yaml:"enabled"`
+	Window       time.Duration     `yaml:"window"`
+	BaseDelay    time.Duration     `yaml:"base_delay"`
+	MaxDelay     time.Duration     `yaml:"max_delay"`
+	LockDuration time.Duration     `yaml:"lock_duration"`
+	User         LoginLimitsConfig `yaml:"user"`
+	IP           LoginLimitsConfig `yaml:"ip"`
+}
+
+// LoginLimitsConfig - пороги неудачных попыток входа для одного счётчика.
+// LockAfter 0 отключает блокировку.
+type LoginLimitsConfig struct {
+	FreeAttempts int `yaml:"free_attempts"`
+	LockAfter    int `yaml:"lock_after"`
+}
+
+// TenancyConfig - настройки арендаторов.
+// DefaultTenant - арендатор запросов без заголовка x-tenant-id,
+// пустое значение делает заголовок обязательным.
+type TenancyConfig struct {
+	DefaultTenant string `yaml:"default_tenant"`
+}
+
 // Load загружает конфигурацию из файла.
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
@@ -102,4 +258,4 @@ func Load(path string) (*Config, error) {
 	}
 
 	return &cfg, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,104 @@
+// Package logger содержит настройку структурированного логирования на slog.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/config"
+)
+
+// Форматы вывода логов.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// New создаёт логгер по настройкам из конфига.
+// Атрибуты, добавленные в контекст через WithAttrs, попадают в каждую запись,
+// залогированную с этим контекстом (slog.InfoContext и т.п.).
+func New(cfg config.LogConfig, w io.Writer) (*slog.Logger, error) {
+	level, err := parseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+
+	switch strings.ToLower(cfg.Format) {
+	case FormatText, "":
+		handler = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+func parseLevel(raw string) (slog.Level, error) {
+	var level slog.Level
+
+	if raw == "" {
+		return slog.LevelInfo, nil
+	}
+
+	if err := level.UnmarshalText([]byte(raw)); err != nil {
+		return level, fmt.Errorf("parse log level: %w", err)
+	}
+
+	return level, nil
+}
+
+type ctxKey struct{}
+
+// WithAttrs возвращает контекст с дополнительными атрибутами логов.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing := attrsFromContext(ctx)
+
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+
+	return context.WithValue(ctx, ctxKey{}, merged)
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+
+	attrs, _ := ctx.Value(ctxKey{}).([]slog.Attr)
+
+	return attrs
+}
+
+// contextHandler добавляет к записи атрибуты из контекста.
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle дописывает атрибуты из контекста и передаёт запись дальше.
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs := attrsFromContext(ctx); len(attrs) > 0 {
+		record.AddAttrs(attrs...)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs возвращает handler с дополнительными атрибутами.
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup возвращает handler с группой атрибутов.
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
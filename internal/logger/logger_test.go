@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/config"
+)
+
+func TestNew_ContextAttrs(t *testing.T) {
+	var buf bytes.Buffer
+
+	log, err := New(config.LogConfig{Level: "info", Format: FormatJSON}, &buf)
+	if err != nil {
+		t.Fatalf("New() unexpected error = %v", err)
+	}
+
+	ctx := WithAttrs(context.Background(), slog.String("request_id", "req-1"))
+	ctx = WithAttrs(ctx, slog.String("method", "/svc/Method"))
+
+	log.DebugContext(ctx, "skipped")
+	log.InfoContext(ctx, "handled")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected exactly one JSON record, got %q: %v", buf.String(), err)
+	}
+
+	if record["request_id"] != "req-1" || record["method"] != "/svc/Method" {
+		t.Errorf("record = %v, want request_id and method from context", record)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	if _, err := New(config.LogConfig{Level: "verbose"}, &bytes.Buffer{}); err == nil {
+		t.Error("New() expected error for unknown level")
+	}
+
+	if _, err := New(config.LogConfig{Format: "xml"}, &bytes.Buffer{}); err == nil {
+		t.Error("New() expected error for unknown format")
+	}
+}
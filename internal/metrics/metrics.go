@@ -10,8 +10,18 @@ type UserMetrics struct {
 	usersCreated atomic.Int64
 	usersDeleted atomic.Int64
 	usersBlocked atomic.Int64
+
+	loginFailures     atomic.Int64
+	loginLockoutsUser atomic.Int64
+	loginLockoutsIP   atomic.Int64
 }
 
+// Области блокировки входа для IncLoginLockouts.
+const (
+	LockoutScopeUser = "user"
+	LockoutScopeIP   = "ip"
+)
+
 // NewUserMetrics создаёт новые метрики пользователей.
 func NewUserMetrics() *UserMetrics {
 	return &UserMetrics{}
@@ -27,12 +37,38 @@ func (m *UserMetrics) IncUsersDeleted() {
 	m.usersDeleted.Add(1)
 }
 
+// AddUsersDeleted увеличивает счётчик удалённых пользователей на n.
+func (m *UserMetrics) AddUsersDeleted(n int) {
+	m.usersDeleted.Add(int64(n))
+}
+
 // IncUsersBlocked увеличивает счётчик заблокированных пользователей.
 func (m *UserMetrics) IncUsersBlocked() {
 	m.usersBlocked.Add(1)
 }
 
+// IncLoginFailures увеличивает счётчик неудачных попыток входа.
+func (m *UserMetrics) IncLoginFailures() {
+	m.loginFailures.Add(1)
+}
+
+// IncLoginLockouts увеличивает счётчик блокировок входа по пользователю
+// (LockoutScopeUser) или по IP клиента (LockoutScopeIP).
+func (m *UserMetrics) IncLoginLockouts(scope string) {
+	switch scope {
+	case LockoutScopeUser:
+		m.loginLockoutsUser.Add(1)
+	case LockoutScopeIP:
+		m.loginLockoutsIP.Add(1)
+	}
+}
+
 // Stats возвращает текущие значения метрик.
 func (m *UserMetrics) Stats() (created, deleted, blocked int64) {
 	return m.usersCreated.Load(), m.usersDeleted.Load(), m.usersBlocked.Load()
+}
+
+// LoginStats возвращает счётчики неудачных попыток входа и блокировок.
+func (m *UserMetrics) LoginStats() (failures, userLockouts, ipLockouts int64) {
+	return m.loginFailures.Load(), m.loginLockoutsUser.Load(), m.loginLockoutsIP.Load()
 }
\ No newline at end of file
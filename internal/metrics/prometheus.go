@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// contentType - content type текстового формата Prometheus.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// loginLockouts - имя счётчика блокировок входа с меткой scope.
+const loginLockouts = "login_lockouts_total"
+
+// NewHandler возвращает HTTP хендлер, отдающий метрики в текстовом формате Prometheus.
+func NewHandler(users *UserMetrics, rpc *RPCMetrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+
+		buf := bufio.NewWriter(w)
+		WritePrometheus(buf, users, rpc)
+		_ = buf.Flush()
+	})
+}
+
+// WritePrometheus пишет метрики в текстовом формате Prometheus.
+func WritePrometheus(w io.Writer, users *UserMetrics, rpc *RPCMetrics) {
+	if users != nil {
+		created, deleted, blocked := users.Stats()
+
+		writeCounter(w, "users_created_total", "Total number of created users.", created)
+		writeCounter(w, "users_deleted_total", "Total number of deleted users.", deleted)
+		writeCounter(w, "users_blocked_total", "Total number of blocked users.", blocked)
+
+		failures, userLockouts, ipLockouts := users.LoginStats()
+
+		writeCounter(w, "login_failures_total", "Total number of failed login attempts.", failures)
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", loginLockouts, "Total number of temporary login lockouts.", loginLockouts)
+		fmt.Fprintf(w, "%s{scope=%q} %d\n", loginLockouts, LockoutScopeUser, userLockouts)
+		fmt.Fprintf(w, "%s{scope=%q} %d\n", loginLockouts, LockoutScopeIP, ipLockouts)
+	}
+
+	if rpc != nil {
+		writeRPC(w, rpc)
+	}
+}
+
+func writeCounter(w io.Writer, name, help string, value int64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", name, help, name, name, value)
+}
+
+func writeRPC(w io.Writer, rpc *RPCMetrics) {
+	keys, stats := rpc.snapshot()
+
+	const (
+		handled  = "grpc_server_handled_total"
+		duration = "grpc_server_handling_seconds"
+	)
+
+	fmt.Fprintf(w, "# HELP %s Total number of RPCs completed on the server.\n# TYPE %s counter\n", handled, handled)
+
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s{%s} %d\n", handled, labels(key), stats[key].count)
+	}
+
+	fmt.Fprintf(w, "# HELP %s Histogram of RPC handling latency in seconds.\n# TYPE %s histogram\n", duration, duration)
+
+	for _, key := range keys {
+		s := stats[key]
+		l := labels(key)
+
+		for i, upper := range rpc.buckets {
+			le := strconv.FormatFloat(upper, 'g', -1, 64)
+			fmt.Fprintf(w, "%s_bucket{%s,le=%q} %d\n", duration, l, le, s.buckets[i])
+		}
+
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", duration, l, s.count)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", duration, l, strconv.FormatFloat(s.sum, 'g', -1, 64))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", duration, l, s.count)
+	}
+}
+
+func labels(key rpcKey) string {
+	return fmt.Sprintf("method=%q,code=%q", key.method, key.code)
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	users := NewUserMetrics()
+	users.IncUsersCreated()
+	users.IncUsersCreated()
+	users.AddUsersDeleted(3)
+	users.IncLoginFailures()
+	users.IncLoginLockouts(LockoutScopeIP)
+
+	rpc := NewRPCMetrics()
+	rpc.Observe("/user_service.UserService/GetUser", "OK", 20*time.Millisecond)
+	rpc.Observe("/user_service.UserService/GetUser", "OK", 2*time.Second)
+
+	var sb strings.Builder
+	WritePrometheus(&sb, users, rpc)
+	out := sb.String()
+
+	want := []string{
+		"# TYPE users_created_total counter\nusers_created_total 2\n",
+		"users_deleted_total 3\n",
+		"users_blocked_total 0\n",
+		"login_failures_total 1\n",
+		`login_lockouts_total{scope="user"} 0`,
+		`login_lockouts_total{scope="ip"} 1`,
+		`grpc_server_handled_total{method="/user_service.UserService/GetUser",code="OK"} 2`,
+		`grpc_server_handling_seconds_bucket{method="/user_service.UserService/GetUser",code="OK",le="0.025"} 1`,
+		`grpc_server_handling_seconds_bucket{method="/user_service.UserService/GetUser",code="OK",le="2.5"} 2`,
+		`grpc_server_handling_seconds_bucket{method="/user_service.UserService/GetUser",code="OK",le="+Inf"} 2`,
+		`grpc_server_handling_seconds_count{method="/user_service.UserService/GetUser",code="OK"} 2`,
+	}
+
+	for _, line := range want {
+		if !strings.Contains(out, line) {
+			t.Errorf("WritePrometheus() output does not contain %q\n%s", line, out)
+		}
+	}
+}
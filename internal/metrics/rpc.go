@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultBuckets - границы бакетов гистограммы латентности в секундах.
+var defaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// rpcKey - набор лейблов метрик RPC.
+type rpcKey struct {
+	method string
+	code   string
+}
+
+// rpcStats - накопленные значения для одного набора лейблов.
+type rpcStats struct {
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+// RPCMetrics - количество и латентность RPC в разрезе метода и кода ответа.
+type RPCMetrics struct {
+	mu      sync.Mutex
+	buckets []float64
+	stats   map[rpcKey]*rpcStats
+}
+
+// NewRPCMetrics создаёт новые метрики RPC.
+func NewRPCMetrics() *RPCMetrics {
+	return &RPCMetrics{
+		buckets: defaultBuckets,
+		stats:   make(map[rpcKey]*rpcStats),
+	}
+}
+
+// Observe учитывает один обработанный запрос.
+func (m *RPCMetrics) Observe(method, code string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := rpcKey{method: method, code: code}
+
+	s, ok := m.stats[key]
+	if !ok {
+		s = &rpcStats{buckets: make([]uint64, len(m.buckets))}
+		m.stats[key] = s
+	}
+
+	seconds := duration.Seconds()
+
+	s.count++
+	s.sum += seconds
+
+	for i, upper := range m.buckets {
+		if seconds <= upper {
+			s.buckets[i]++
+		}
+	}
+}
+
+// snapshot возвращает копию накопленных значений, отсортированную по лейблам.
+func (m *RPCMetrics) snapshot() ([]rpcKey, map[rpcKey]rpcStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]rpcKey, 0, len(m.stats))
+	stats := make(map[rpcKey]rpcStats, len(m.stats))
+
+	for key, s := range m.stats {
+		keys = append(keys, key)
+		stats[key] = rpcStats{
+			count:   s.count,
+			sum:     s.sum,
+			buckets: append([]uint64(nil), s.buckets...),
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+
+	return keys, stats
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// AuthenticateInput - входные данные для аутентификации.
+type AuthenticateInput struct {
+	Email    string
+	Password string
+	Client   ClientInfo
+}
+
+// TokenClaims - данные, зашитые в access токен.
+type TokenClaims struct {
+	UserID    string
+	TenantID  string
+	SessionID string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// AccessToken - подписанный access токен.
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// AuthResult - результат успешной аутентификации.
+// Если у пользователя включена MFA, вход ещё не завершён: заполнены только
+// User и MFAChallenge, а сессия и токены выдаются после VerifyMFA.
+type AuthResult struct {
+	User         *User
+	Session      *Session
+	AccessToken  AccessToken
+	RefreshToken RefreshToken
+	MFAChallenge *MFAChallengeToken
+}
+
+// MFAChallengeToken - токен второго шага входа, выданный клиенту.
+type MFAChallengeToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// EmailVerification - одноразовый токен подтверждения email.
+// Email - подтверждаемый адрес: текущий email пользователя после регистрации
+// или новый адрес при смене email. Сам токен не хранится, только его хэш.
+type EmailVerification struct {
+	ID              string
+	UserID          string
+	Email           string
+	EmailNormalized string
+	TokenHash       string
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+	UsedAt          *time.Time
+}
+
+// IsActive проверяет, что токен не использован и не истёк на момент now.
+func (v *EmailVerification) IsActive(now time.Time) bool {
+	return v.UsedAt == nil && now.Before(v.ExpiresAt)
+}
+
+// EmailVerificationFilter - фильтры для поиска токенов подтверждения email.
+// Пустой слайс означает "без фильтра по этому полю".
+type EmailVerificationFilter struct {
+	IDs         []string
+	UserIDs     []string
+	TokenHashes []string
+	// CreatedAfter оставляет только токены, выпущенные после указанного момента.
+	CreatedAfter *time.Time
+	// ActiveAt оставляет только токены, активные на указанный момент.
+	ActiveAt *time.Time
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// LoginFailures - счётчик неудачных попыток входа по ключу: email
+// пользователя или IP клиента. Счётчик сбрасывается после успешного
+// входа и сам начинается заново, если ошибок не было дольше окна.
+type LoginFailures struct {
+	Key           string
+	Count         int
+	LastFailureAt time.Time
+}
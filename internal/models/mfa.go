@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// TOTPEnrollment - данные для подключения приложения-аутентификатора:
+// секрет в base32 для ручного ввода и otpauth:// URI для QR-кода.
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// MFAChallenge - одноразовый токен второго шага входа, выдаётся после
+// проверки пароля пользователю с включённой MFA. Сам токен не хранится,
+// только его хэш. Attempts - количество неверных кодов по токену.
+type MFAChallenge struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	Attempts  int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// IsActive проверяет, что токен не использован и не истёк на момент now.
+func (c *MFAChallenge) IsActive(now time.Time) bool {
+	return c.UsedAt == nil && now.Before(c.ExpiresAt)
+}
+
+// MFAChallengeFilter - фильтры для поиска токенов второго шага входа.
+// Пустой слайс означает "без фильтра по этому полю".
+type MFAChallengeFilter struct {
+	IDs         []string
+	UserIDs     []string
+	TokenHashes []string
+	// ActiveAt оставляет только токены, активные на указанный момент.
+	ActiveAt *time.Time
+}
+
+// VerifyMFAInput - входные данные второго шага входа. Code - код из
+// приложения-аутентификатора или один из кодов восстановления.
+type VerifyMFAInput struct {
+	Token  string
+	Code   string
+	Client ClientInfo
+}
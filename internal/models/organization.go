@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// Organization - организация (группа) пользователей одного арендатора.
+type Organization struct {
+	ID        string
+	TenantID  string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrganizationFilter - фильтры для поиска организаций.
+// Пустой слайс означает "без фильтра по этому полю".
+type OrganizationFilter struct {
+	IDs []string
+}
+
+// CreateOrganizationInput - входные данные для создания организации.
+type CreateOrganizationInput struct {
+	Name string
+}
+
+// Membership - членство пользователя в организации.
+type Membership struct {
+	OrganizationID string
+	UserID         string
+	Role           types.MemberRole
+	CreatedAt      time.Time
+}
+
+// MembershipFilter - фильтры для поиска и удаления членства.
+// Пустой слайс означает "без фильтра по этому полю".
+type MembershipFilter struct {
+	OrganizationIDs []string
+	UserIDs         []string
+}
+
+// AddMemberInput - входные данные для добавления участника организации.
+type AddMemberInput struct {
+	OrganizationID string
+	UserID         string
+	Role           types.MemberRole
+}
+
+// UserOrganization - организация пользователя и его роль в ней.
+type UserOrganization struct {
+	Organization *Organization
+	Role         types.MemberRole
+	JoinedAt     time.Time
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// PasswordReset - одноразовый токен сброса пароля.
+// Сам токен не хранится, только его хэш.
+type PasswordReset struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// IsActive проверяет, что токен не использован и не истёк на момент now.
+func (r *PasswordReset) IsActive(now time.Time) bool {
+	return r.UsedAt == nil && now.Before(r.ExpiresAt)
+}
+
+// PasswordResetFilter - фильтры для поиска токенов сброса пароля.
+// Пустой слайс означает "без фильтра по этому полю".
+type PasswordResetFilter struct {
+	IDs         []string
+	UserIDs     []string
+	TokenHashes []string
+	// CreatedAfter оставляет только токены, выпущенные после указанного момента.
+	CreatedAfter *time.Time
+	// ActiveAt оставляет только токены, активные на указанный момент.
+	ActiveAt *time.Time
+}
+
+// ConfirmPasswordResetInput - входные данные для установки пароля по токену сброса.
+type ConfirmPasswordResetInput struct {
+	Token       string
+	NewPassword string
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchTerms разбивает текст на термы полнотекстового поиска:
+// последовательности букв и цифр в нижнем регистре.
+// Email "Alice.Smith@acme.com" даёт термы alice, smith, acme, com.
+func SearchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
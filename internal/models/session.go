@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Session - сессия пользователя, привязанная к refresh токену.
+// Сам refresh токен не хранится, только его хэш.
+type Session struct {
+	ID               string
+	UserID           string
+	RefreshTokenHash string
+	UserAgent        string
+	IP               string
+	CreatedAt        time.Time
+	LastUsedAt       time.Time
+	ExpiresAt        time.Time
+	RevokedAt        *time.Time
+}
+
+// IsActive проверяет, что сессия не отозвана и не истекла на момент now.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// SessionFilter - фильтры для поиска сессий.
+// Пустой слайс означает "без фильтра по этому полю".
+type SessionFilter struct {
+	IDs                []string
+	UserIDs            []string
+	RefreshTokenHashes []string
+	// ActiveAt оставляет только сессии, активные на указанный момент.
+	ActiveAt *time.Time
+}
+
+// ClientInfo - данные о клиенте, с которого пришёл запрос.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// RefreshToken - выпущенный refresh токен и его хэш для хранения.
+type RefreshToken struct {
+	Token     string
+	Hash      string
+	ExpiresAt time.Time
+}
@@ -2,20 +2,43 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/obsessed-gopher/micro-service-guide/internal/types"
 )
 
 // User - бизнес-модель пользователя.
+// TenantID - арендатор пользователя: выставляется репозиторием из контекста
+// при создании и не меняется; email уникален в пределах арендатора.
+// Version увеличивается при каждом обновлении и используется для optimistic locking.
+// DeletedAt выставляется при мягком удалении, такие пользователи скрыты из выборок.
+// Email хранится в том виде, в каком его ввёл пользователь, а уникальность
+// и поиск по email проверяются по каноничной форме EmailNormalized.
+// PasswordChangedAt - время установки текущего пароля.
+// EmailVerifiedAt выставляется, когда пользователь подтвердил владение текущим email.
+// TOTPSecret хранится зашифрованным; TOTPEnabledAt выставляется после
+// подтверждения первым кодом, до этого подключение MFA не завершено.
+// TOTPLastStep - временной шаг последнего принятого кода, защищает от его
+// повторного использования. RecoveryCodeHashes - хэши неиспользованных кодов восстановления.
 type User struct {
-	ID           string
-	Email        string
-	Name         string
-	PasswordHash string
-	Status       types.UserStatus
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID                 string
+	TenantID           string
+	Email              string
+	EmailNormalized    string
+	EmailVerifiedAt    *time.Time
+	Name               string
+	PasswordHash       string
+	PasswordChangedAt  time.Time
+	TOTPSecret         string
+	TOTPEnabledAt      *time.Time
+	TOTPLastStep       int64
+	RecoveryCodeHashes []string
+	Status             types.UserStatus
+	Version            int64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DeletedAt          *time.Time
 }
 
 // IsActive проверяет, активен ли пользователь.
@@ -28,6 +51,30 @@ func (u *User) IsBlocked() bool {
 	return u.Status == types.UserStatusBlocked
 }
 
+// IsDeleted проверяет, удалён ли пользователь.
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// IsEmailVerified проверяет, подтверждён ли email пользователя.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsMFAEnabled проверяет, включена ли у пользователя двухфакторная аутентификация.
+func (u *User) IsMFAEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// EffectiveStatus возвращает статус для клиентов с учётом удаления.
+func (u *User) EffectiveStatus() types.UserStatus {
+	if u.IsDeleted() {
+		return types.UserStatusDeleted
+	}
+
+	return u.Status
+}
+
 // CreateUserInput - входные данные для создания пользователя.
 type CreateUserInput struct {
 	Email    string
@@ -35,24 +82,191 @@ type CreateUserInput struct {
 	Password string
 }
 
+// ChangePasswordInput - входные данные для смены пароля пользователем.
+type ChangePasswordInput struct {
+	OldPassword string
+	NewPassword string
+	// RevokeSessions - отозвать все сессии, выданные со старым паролем.
+	RevokeSessions bool
+}
+
+// SetPasswordInput - входные данные для установки пароля администратором.
+type SetPasswordInput struct {
+	Password       string
+	RevokeSessions bool
+}
+
 // UpdateUserInput - входные данные для обновления пользователя.
 type UpdateUserInput struct {
 	Email  *string
 	Name   *string
 	Status *types.UserStatus
+	// ExpectedVersion - версия, которую видел клиент. Если задана и не совпадает
+	// с текущей, обновление отклоняется с ErrVersionConflict.
+	ExpectedVersion *int64
 }
 
 // UserFilter - фильтры для поиска пользователей.
-// Все поля — слайсы для поддержки множественных значений (IN).
-// Пустой слайс означает "без фильтра по этому полю".
+// Emails сравниваются с каноничной формой email (User.EmailNormalized).
+// Слайсы поддерживают множественные значения (IN), пустой слайс и нулевые
+// значения остальных полей означают "без фильтра по этому полю".
+// Все заданные условия объединяются через AND.
+// Удалённые пользователи исключаются, если не задан IncludeDeleted.
 type UserFilter struct {
-	IDs      []string
-	Emails   []string
-	Statuses []types.UserStatus
+	IDs             []string
+	Emails          []string
+	Statuses        []types.UserStatus
+	ExcludeStatuses []types.UserStatus
+	// EmailDomains - домены email без учёта регистра, например "acme.com".
+	EmailDomains   []string
+	EmailMatch     StringMatch
+	NameMatch      StringMatch
+	CreatedAt      TimeRange
+	UpdatedAt      TimeRange
+	IncludeDeleted bool
 }
 
-// Pagination - параметры пагинации.
+// StringMatch - регистронезависимый поиск по строке: по началу (Prefix)
+// или по подстроке. Пустой Value означает "без фильтра".
+type StringMatch struct {
+	Value  string
+	Prefix bool
+}
+
+// IsSet проверяет, задан ли фильтр.
+func (m StringMatch) IsSet() bool {
+	return m.Value != ""
+}
+
+// Matches проверяет, подходит ли строка под фильтр.
+func (m StringMatch) Matches(s string) bool {
+	s, value := strings.ToLower(s), strings.ToLower(m.Value)
+
+	if m.Prefix {
+		return strings.HasPrefix(s, value)
+	}
+
+	return strings.Contains(s, value)
+}
+
+// TimeRange - полуинтервал времени [From, To). Незаданная граница не ограничивает.
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// Contains проверяет, попадает ли время в интервал.
+func (r TimeRange) Contains(t time.Time) bool {
+	if r.From != nil && t.Before(*r.From) {
+		return false
+	}
+
+	if r.To != nil && !t.Before(*r.To) {
+		return false
+	}
+
+	return true
+}
+
+// EmailDomain возвращает домен email в нижнем регистре.
+func EmailDomain(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return ""
+	}
+
+	return strings.ToLower(email[at+1:])
+}
+
+// Pagination - параметры пагинации и сортировки.
+// Если задан After, выборка продолжается после курсора и Offset не применяется.
+// Пустой Sort означает сортировку по умолчанию (DefaultSort).
 type Pagination struct {
 	Limit  int
 	Offset int
+	After  *Cursor
+	Sort   []SortOrder
+}
+
+// SortField - поле сортировки пользователей.
+type SortField string
+
+// Поля, по которым можно сортировать пользователей.
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByEmail     SortField = "email"
+	SortByName      SortField = "name"
+)
+
+// SortOrder - ключ сортировки.
+type SortOrder struct {
+	Field SortField
+	Desc  bool
+}
+
+// DefaultSort - сортировка по умолчанию: новые пользователи первыми.
+var DefaultSort = []SortOrder{{Field: SortByCreatedAt, Desc: true}}
+
+// Cursor - позиция keyset пагинации: ключи сортировки последнего элемента
+// предыдущей страницы. ID - последний ключ, делающий порядок однозначным.
+type Cursor struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Email     string
+	Name      string
+	ID        string
+}
+
+// CursorOf возвращает курсор, указывающий на пользователя.
+func CursorOf(u *User) *Cursor {
+	return &Cursor{
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+		Email:     u.Email,
+		Name:      u.Name,
+		ID:        u.ID,
+	}
+}
+
+// CompareCursors сравнивает позиции a и b при сортировке orders:
+// отрицательное значение - a идёт раньше b. При равенстве всех ключей
+// сравнивается ID в направлении последнего ключа. Строки сравниваются
+// побайтово; PostgreSQL репозиторий сортирует их с COLLATE "C", чтобы
+// порядок не зависел от collation базы.
+func CompareCursors(a, b *Cursor, orders []SortOrder) int {
+	if len(orders) == 0 {
+		orders = DefaultSort
+	}
+
+	for _, order := range orders {
+		if c := applyDirection(compareField(a, b, order.Field), order.Desc); c != 0 {
+			return c
+		}
+	}
+
+	return applyDirection(strings.Compare(a.ID, b.ID), orders[len(orders)-1].Desc)
+}
+
+func compareField(a, b *Cursor, field SortField) int {
+	switch field {
+	case SortByCreatedAt:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case SortByUpdatedAt:
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case SortByEmail:
+		return strings.Compare(a.Email, b.Email)
+	case SortByName:
+		return strings.Compare(a.Name, b.Name)
+	default:
+		return 0
+	}
+}
+
+func applyDirection(c int, desc bool) int {
+	if desc {
+		return -c
+	}
+
+	return c
 }
@@ -0,0 +1,77 @@
+// Package tenant передаёт арендатора (tenant) запроса через контекст.
+// Репозитории пользователей читают его из контекста и ограничивают
+// им каждый запрос, поэтому забытый в хендлере фильтр не открывает
+// доступ к данным другого арендатора.
+package tenant
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// Header - заголовок (metadata) с идентификатором арендатора.
+const Header = "x-tenant-id"
+
+// idPattern - допустимый формат идентификатора арендатора.
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// scope - арендатор в контексте. all - системная операция над всеми арендаторами.
+type scope struct {
+	id  string
+	all bool
+}
+
+type ctxKey struct{}
+
+// Resolve возвращает арендатора из заголовка или defaultID, если заголовок пуст.
+func Resolve(header, defaultID string) (string, error) {
+	id := header
+	if id == "" {
+		id = defaultID
+	}
+
+	if id == "" {
+		return "", types.ErrTenantRequired
+	}
+
+	if !idPattern.MatchString(id) {
+		return "", types.ErrInvalidTenant
+	}
+
+	return id, nil
+}
+
+// WithID кладёт арендатора в контекст.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, scope{id: id})
+}
+
+// WithAll помечает контекст системной операцией над всеми арендаторами,
+// например фоновой очисткой удалённых пользователей.
+func WithAll(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey{}, scope{all: true})
+}
+
+// FromContext возвращает арендатора из контекста.
+func FromContext(ctx context.Context) (string, bool) {
+	s, ok := ctx.Value(ctxKey{}).(scope)
+	if !ok || s.all {
+		return "", false
+	}
+
+	return s.id, true
+}
+
+// Scope возвращает арендатора, которым нужно ограничить запрос.
+// all - операция над всеми арендаторами. Без арендатора в контексте
+// возвращает types.ErrTenantRequired.
+func Scope(ctx context.Context) (id string, all bool, err error) {
+	s, ok := ctx.Value(ctxKey{}).(scope)
+	if !ok {
+		return "", false, types.ErrTenantRequired
+	}
+
+	return s.id, s.all, nil
+}
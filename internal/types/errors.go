@@ -9,7 +9,36 @@ var (
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrInvalidEmail      = errors.New("invalid email format")
 	ErrInvalidPassword   = errors.New("password does not meet requirements")
+	ErrWrongPassword     = errors.New("current password is incorrect")
 	ErrUserBlocked       = errors.New("user is blocked")
+	ErrUserInactive      = errors.New("user is inactive")
+	ErrVersionConflict   = errors.New("user was modified concurrently")
+	ErrInvalidStatus     = errors.New("invalid user status")
+	ErrInvalidPageToken  = errors.New("invalid page token")
+	ErrInvalidOrderBy    = errors.New("invalid order_by")
+	ErrEmptySearchQuery  = errors.New("search query is empty")
+
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrTooManyAttempts    = errors.New("too many failed login attempts")
+
+	ErrInvalidMFACode    = errors.New("invalid two-factor authentication code")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+
+	ErrUnauthenticated  = errors.New("authentication required")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrInvalidRole      = errors.New("invalid role")
+
+	ErrTenantRequired = errors.New("tenant is required")
+	ErrInvalidTenant  = errors.New("invalid tenant id")
+
+	ErrOrganizationNotFound    = errors.New("organization not found")
+	ErrInvalidOrganizationName = errors.New("invalid organization name")
+	ErrMemberNotFound          = errors.New("organization member not found")
+	ErrMemberAlreadyExists     = errors.New("user is already an organization member")
+	ErrInvalidMemberRole       = errors.New("invalid organization member role")
 )
 
 // IsNotFound проверяет, является ли ошибка "не найдено".
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginThrottledError - вход временно запрещён из-за неудачных попыток.
+// Locked - попыток слишком много и вход заблокирован на длительный срок,
+// иначе это короткая задержка перед следующей попыткой.
+// Сравнивается с ErrTooManyAttempts через errors.Is.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+// Error возвращает текст ошибки со временем до следующей попытки.
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %ds", ErrTooManyAttempts, e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds возвращает время до следующей попытки в целых
+// секундах с округлением вверх, не меньше одной.
+func (e *LoginThrottledError) RetryAfterSeconds() int {
+	return max(int((e.RetryAfter+time.Second-1)/time.Second), 1)
+}
+
+// Unwrap позволяет сравнивать ошибку с ErrTooManyAttempts.
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrTooManyAttempts
+}
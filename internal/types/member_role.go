@@ -0,0 +1,18 @@
+package types
+
+// MemberRole - роль пользователя в организации.
+type MemberRole string
+
+const (
+	// MemberRoleOwner - владелец организации.
+	MemberRoleOwner MemberRole = "owner"
+	// MemberRoleAdmin - управляет составом организации.
+	MemberRoleAdmin MemberRole = "admin"
+	// MemberRoleMember - рядовой участник.
+	MemberRoleMember MemberRole = "member"
+)
+
+// IsValid проверяет, что роль известна.
+func (r MemberRole) IsValid() bool {
+	return r == MemberRoleOwner || r == MemberRoleAdmin || r == MemberRoleMember
+}
@@ -0,0 +1,42 @@
+package types
+
+import "strings"
+
+// Причины, по которым пароль не проходит политику.
+const (
+	PasswordTooShort       = "too_short"
+	PasswordTooLong        = "too_long"
+	PasswordNoLowercase    = "missing_lowercase"
+	PasswordNoUppercase    = "missing_uppercase"
+	PasswordNoDigit        = "missing_digit"
+	PasswordNoSymbol       = "missing_symbol"
+	PasswordPersonalInfo   = "contains_personal_info"
+	PasswordCommonOrLeaked = "breached"
+)
+
+// PasswordViolation - нарушение политики паролей.
+type PasswordViolation struct {
+	Reason  string // машиночитаемая причина, одна из констант Password*
+	Message string // описание для пользователя
+}
+
+// PasswordPolicyError - пароль не прошёл политику.
+// Сравнивается с ErrInvalidPassword через errors.Is.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+// Error возвращает текст ошибки со всеми нарушениями.
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+
+	return ErrInvalidPassword.Error() + ": " + strings.Join(messages, "; ")
+}
+
+// Unwrap позволяет сравнивать ошибку с ErrInvalidPassword.
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrInvalidPassword
+}
@@ -0,0 +1,60 @@
+package types
+
+import "slices"
+
+// Role - роль пользователя, определяющая его права.
+type Role string
+
+const (
+	// RoleAdmin - полный доступ ко всем пользователям и организациям.
+	RoleAdmin Role = "admin"
+	// RoleSupport - просмотр пользователей и организаций, блокировка и разблокировка.
+	RoleSupport Role = "support"
+	// RoleSelfService - просмотр и изменение только своей записи, просмотр своих организаций.
+	// Выдаётся каждому пользователю при создании.
+	RoleSelfService Role = "self_service"
+)
+
+// Permission - право на действие с пользователями или организациями.
+type Permission string
+
+const (
+	PermissionUsersRead   Permission = "users.read"
+	PermissionUsersWrite  Permission = "users.write"
+	PermissionUsersDelete Permission = "users.delete"
+	PermissionUsersBlock  Permission = "users.block"
+	PermissionUsersRoles  Permission = "users.roles"
+	PermissionOrgsRead    Permission = "orgs.read"
+	PermissionOrgsWrite   Permission = "orgs.write"
+)
+
+// rolePermissions - права ролей на любых пользователей.
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermissionUsersRead, PermissionUsersWrite, PermissionUsersDelete,
+		PermissionUsersBlock, PermissionUsersRoles,
+		PermissionOrgsRead, PermissionOrgsWrite,
+	},
+	RoleSupport: {PermissionUsersRead, PermissionUsersBlock, PermissionOrgsRead},
+}
+
+// roleSelfPermissions - права ролей только на собственную запись.
+var roleSelfPermissions = map[Role][]Permission{
+	RoleSelfService: {PermissionUsersRead, PermissionUsersWrite, PermissionOrgsRead},
+}
+
+// IsValid проверяет, что роль известна.
+func (r Role) IsValid() bool {
+	return r == RoleAdmin || r == RoleSupport || r == RoleSelfService
+}
+
+// Permissions возвращает права роли на любых пользователей.
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// SelfPermissions возвращает права роли на собственную запись,
+// включая права на любых пользователей.
+func (r Role) SelfPermissions() []Permission {
+	return slices.Concat(r.Permissions(), roleSelfPermissions[r])
+}
@@ -8,6 +8,9 @@ const (
 	UserStatusActive
 	UserStatusInactive
 	UserStatusBlocked
+	// UserStatusDeleted не хранится в БД: это производный статус
+	// мягко удалённого пользователя, отдаваемый клиентам.
+	UserStatusDeleted
 )
 
 // String возвращает строковое представление статуса.
@@ -19,12 +22,15 @@ func (s UserStatus) String() string {
 		return "inactive"
 	case UserStatusBlocked:
 		return "blocked"
+	case UserStatusDeleted:
+		return "deleted"
 	default:
 		return "unspecified"
 	}
 }
 
 // IsValid проверяет валидность статуса.
+// UserStatusDeleted невалиден: удаление выполняется только через Delete.
 func (s UserStatus) IsValid() bool {
 	return s >= UserStatusActive && s <= UserStatusBlocked
 }
\ No newline at end of file
@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// Authenticate проверяет email и пароль, открывает сессию и выпускает токены.
+// Для несуществующего пользователя и неверного пароля возвращается
+// одна и та же ошибка, чтобы не раскрывать наличие аккаунта.
+// Если у пользователя включена MFA, вместо сессии выдаётся токен второго
+// шага, и вход завершается через VerifyMFA. После серии неудачных попыток
+// вход временно запрещается с *types.LoginThrottledError.
+func (m *UserUsecase) Authenticate(ctx context.Context, input models.AuthenticateInput) (*models.AuthResult, error) {
+	email, err := m.normalizeEmail(input.Email)
+	if err != nil {
+		return nil, types.ErrInvalidCredentials
+	}
+
+	attempt, err := m.reserveLoginAttempt(ctx, email, input.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := m.findOne(ctx, models.UserFilter{Emails: []string{email}})
+	if err != nil {
+		if types.IsNotFound(err) {
+			// Сравнение с фиктивным хэшем выравнивает время ответа,
+			// иначе по нему можно отличить несуществующий аккаунт.
+			m.hasher.Compare(m.dummyHash(), input.Password)
+			return nil, m.loginFailed(ctx, attempt)
+		}
+		return nil, m.abortLoginAttempt(ctx, attempt, fmt.Errorf("get user: %w", err))
+	}
+
+	if !m.hasher.Compare(user.PasswordHash, input.Password) {
+		slog.InfoContext(ctx, "authentication failed", slog.String("user_id", user.ID))
+		return nil, m.loginFailed(ctx, attempt)
+	}
+
+	if err := checkCanLogin(user); err != nil {
+		if releaseErr := m.releaseLoginAttempt(ctx, attempt); releaseErr != nil {
+			return nil, releaseErr
+		}
+		return nil, err
+	}
+
+	m.rehashPassword(ctx, user, input.Password)
+
+	// Счётчик пользователя с MFA сбрасывается только после второго шага,
+	// иначе знание пароля позволяло бы перебирать коды без ограничений.
+	if user.IsMFAEnabled() {
+		if err := m.releaseLoginAttempt(ctx, attempt); err != nil {
+			return nil, err
+		}
+		return m.startMFAChallenge(ctx, user)
+	}
+
+	if err := m.succeedLoginAttempt(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	return m.openSession(ctx, user, input.Client)
+}
+
+// dummyPassword - пароль фиктивного хэша для входа несуществующего пользователя.
+const dummyPassword = "dummy-password-for-timing"
+
+// newDummyHash возвращает функцию, лениво считающую фиктивный хэш текущим
+// алгоритмом и параметрами hasher, чтобы его проверка длилась как настоящая.
+func newDummyHash(hasher PasswordHasher) func() string {
+	return sync.OnceValue(func() string {
+		hash, err := hasher.Hash(dummyPassword)
+		if err != nil {
+			slog.Warn("failed to compute dummy password hash", slog.Any("error", err))
+		}
+
+		return hash
+	})
+}
+
+// rehashPassword пересчитывает хэш пароля текущим алгоритмом, если он
+// устарел. Ошибки только логируются: вход не должен зависеть от апгрейда хэша.
+func (m *UserUsecase) rehashPassword(ctx context.Context, user *models.User, password string) {
+	if !m.hasher.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	hash, err := m.hasher.Hash(password)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to rehash password", slog.String("user_id", user.ID), slog.Any("error", err))
+		return
+	}
+
+	old := user.PasswordHash
+	user.PasswordHash = hash
+
+	if err := m.repo.Update(ctx, user); err != nil {
+		user.PasswordHash = old
+		slog.WarnContext(ctx, "failed to save rehashed password", slog.String("user_id", user.ID), slog.Any("error", err))
+		return
+	}
+
+	slog.InfoContext(ctx, "password rehashed", slog.String("user_id", user.ID))
+}
+
+// loginFailed учитывает неверный пароль и возвращает ошибку для клиента.
+func (m *UserUsecase) loginFailed(ctx context.Context, attempt *loginAttempt) error {
+	m.failLoginAttempt(ctx, attempt)
+	return types.ErrInvalidCredentials
+}
+
+// openSession открывает сессию пользователя и выпускает токены.
+func (m *UserUsecase) openSession(ctx context.Context, user *models.User, client models.ClientInfo) (*models.AuthResult, error) {
+	refresh, err := m.tokens.IssueRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("issue refresh token: %w", err)
+	}
+
+	now := time.Now()
+	session := &models.Session{
+		ID:               m.idGen.Generate(),
+		UserID:           user.ID,
+		RefreshTokenHash: refresh.Hash,
+		UserAgent:        client.UserAgent,
+		IP:               client.IP,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        refresh.ExpiresAt,
+	}
+
+	if err := m.sessions.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+
+	slog.InfoContext(ctx, "session opened",
+		slog.String("user_id", user.ID),
+		slog.String("session_id", session.ID),
+	)
+
+	return m.issueTokens(user, session, refresh)
+}
+
+// checkCanLogin проверяет, что пользователю разрешено получать токены.
+func checkCanLogin(user *models.User) error {
+	if user.IsBlocked() {
+		return types.ErrUserBlocked
+	}
+
+	if !user.IsActive() {
+		return types.ErrUserInactive
+	}
+
+	return nil
+}
+
+// issueTokens выпускает access токен для сессии и собирает результат.
+func (m *UserUsecase) issueTokens(
+	user *models.User,
+	session *models.Session,
+	refresh models.RefreshToken,
+) (*models.AuthResult, error) {
+	access, err := m.tokens.IssueAccessToken(models.TokenClaims{
+		UserID:    user.ID,
+		TenantID:  user.TenantID,
+		SessionID: session.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("issue access token: %w", err)
+	}
+
+	return &models.AuthResult{
+		User:         user,
+		Session:      session,
+		AccessToken:  access,
+		RefreshToken: refresh,
+	}, nil
+}
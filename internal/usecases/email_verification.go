@@ -0,0 +1,201 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// errEmailVerificationDisabled - подтверждение email не настроено через WithEmailVerification.
+var errEmailVerificationDisabled = errors.New("email verification is not configured")
+
+// EmailVerificationRepository - интерфейс репозитория токенов подтверждения email.
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, verification *models.EmailVerification) error
+	Find(ctx context.Context, filter models.EmailVerificationFilter) ([]*models.EmailVerification, error)
+	Count(ctx context.Context, filter models.EmailVerificationFilter) (int, error)
+	// Use помечает неиспользованные токены по фильтру использованными.
+	// Возвращает количество помеченных.
+	Use(ctx context.Context, filter models.EmailVerificationFilter, usedAt time.Time) (int, error)
+}
+
+// EmailVerificationSettings - настройки подтверждения email.
+type EmailVerificationSettings struct {
+	TokenTTL time.Duration
+	// RequireVerification - новые пользователи создаются неактивными
+	// и активируются после подтверждения email.
+	RequireVerification bool
+	// MaxRequests - сколько токенов можно выпустить одному пользователю за Window.
+	// Отправки сверх лимита, в том числе при смене email, молча игнорируются.
+	MaxRequests int
+	Window      time.Duration
+}
+
+// ResendEmailVerification повторно отправляет токен подтверждения на текущий
+// email пользователя, если он ещё не подтверждён. Нужна, когда письмо после
+// регистрации потерялось или токен истёк: неактивный пользователь не может
+// войти и сменить email. Ответ не зависит от того, существует ли email.
+func (m *UserUsecase) ResendEmailVerification(ctx context.Context, email string) error {
+	if m.verifications == nil {
+		return errEmailVerificationDisabled
+	}
+
+	normalized, err := m.normalizeEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	user, err := m.findOne(ctx, models.UserFilter{Emails: []string{normalized}})
+	if err != nil {
+		if types.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if user.IsBlocked() || user.IsEmailVerified() {
+		slog.InfoContext(ctx, "email verification resend skipped", slog.String("user_id", user.ID))
+		return nil
+	}
+
+	return m.sendEmailVerification(ctx, user, user.Email, user.EmailNormalized)
+}
+
+// sendEmailVerification выпускает токен подтверждения адреса email для пользователя
+// и отправляет его на этот адрес. Лимит MaxRequests общий для регистрации,
+// смены email и повторной отправки, иначе сменой email можно было бы
+// без ограничений слать письма на произвольные адреса.
+func (m *UserUsecase) sendEmailVerification(ctx context.Context, user *models.User, email, normalized string) error {
+	if m.verificationSettings.MaxRequests > 0 {
+		windowStart := time.Now().Add(-m.verificationSettings.Window)
+
+		count, err := m.verifications.Count(ctx, models.EmailVerificationFilter{
+			UserIDs:      []string{user.ID},
+			CreatedAfter: &windowStart,
+		})
+		if err != nil {
+			return fmt.Errorf("count email verifications: %w", err)
+		}
+
+		if count >= m.verificationSettings.MaxRequests {
+			slog.InfoContext(ctx, "email verification rate limited", slog.String("user_id", user.ID))
+			return nil
+		}
+	}
+
+	token, hash, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	verification := &models.EmailVerification{
+		ID:              m.idGen.Generate(),
+		UserID:          user.ID,
+		Email:           email,
+		EmailNormalized: normalized,
+		TokenHash:       hash,
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(m.verificationSettings.TokenTTL),
+	}
+
+	if err := m.verifications.Create(ctx, verification); err != nil {
+		return fmt.Errorf("create email verification: %w", err)
+	}
+
+	if err := m.notifier.SendEmailVerification(ctx, user, email, token, verification.ExpiresAt); err != nil {
+		return fmt.Errorf("send email verification: %w", err)
+	}
+
+	slog.InfoContext(ctx, "email verification sent", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+// VerifyEmail подтверждает email по токену. Если токен выпущен на новый адрес,
+// email пользователя меняется на него. При включённом RequireVerification
+// пользователь, ещё не подтверждавший email, становится активным.
+func (m *UserUsecase) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	if m.verifications == nil {
+		return nil, types.ErrInvalidToken
+	}
+
+	now := time.Now()
+
+	verifications, err := m.verifications.Find(ctx, models.EmailVerificationFilter{
+		TokenHashes: []string{hashSecretToken(token)},
+		ActiveAt:    &now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find email verification: %w", err)
+	}
+
+	if len(verifications) == 0 {
+		return nil, types.ErrInvalidToken
+	}
+
+	verification := verifications[0]
+
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{verification.UserID}})
+	if err != nil {
+		if types.IsNotFound(err) {
+			return nil, types.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if user.IsBlocked() {
+		return nil, types.ErrUserBlocked
+	}
+
+	// Пока письмо шло, новый адрес мог занять другой пользователь.
+	if verification.EmailNormalized != user.EmailNormalized {
+		if err := m.checkEmailAvailable(ctx, verification.EmailNormalized, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	used, err := m.verifications.Use(ctx, models.EmailVerificationFilter{
+		IDs:      []string{verification.ID},
+		ActiveAt: &now,
+	}, now)
+	if err != nil {
+		return nil, fmt.Errorf("use email verification: %w", err)
+	}
+
+	if used == 0 {
+		return nil, types.ErrInvalidToken
+	}
+
+	// После смены адреса остальные токены пользователя указывают на чужие адреса.
+	if verification.EmailNormalized != user.EmailNormalized {
+		if _, err := m.verifications.Use(ctx, models.EmailVerificationFilter{
+			UserIDs:  []string{user.ID},
+			ActiveAt: &now,
+		}, now); err != nil {
+			return nil, fmt.Errorf("use email verifications: %w", err)
+		}
+	}
+
+	if m.verificationSettings.RequireVerification && !user.IsEmailVerified() && user.Status == types.UserStatusInactive {
+		user.Status = types.UserStatusActive
+	}
+
+	user.Email = verification.Email
+	user.EmailNormalized = verification.EmailNormalized
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+
+	if err := m.repo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("verify email: %w", err)
+	}
+
+	slog.InfoContext(ctx, "email verified", slog.String("user_id", user.ID))
+
+	return user, nil
+}
@@ -0,0 +1,233 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+type mockEmailVerificationRepository struct {
+	verifications map[string]*models.EmailVerification
+}
+
+func newMockEmailVerificationRepository() *mockEmailVerificationRepository {
+	return &mockEmailVerificationRepository{verifications: make(map[string]*models.EmailVerification)}
+}
+
+func (m *mockEmailVerificationRepository) Create(ctx context.Context, verification *models.EmailVerification) error {
+	stored := *verification
+	m.verifications[verification.ID] = &stored
+	return nil
+}
+
+func (m *mockEmailVerificationRepository) Find(ctx context.Context, filter models.EmailVerificationFilter) ([]*models.EmailVerification, error) {
+	var result []*models.EmailVerification
+	for _, v := range m.verifications {
+		if m.matchesFilter(v, filter) {
+			found := *v
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockEmailVerificationRepository) Count(ctx context.Context, filter models.EmailVerificationFilter) (int, error) {
+	found, err := m.Find(ctx, filter)
+	return len(found), err
+}
+
+func (m *mockEmailVerificationRepository) Use(ctx context.Context, filter models.EmailVerificationFilter, usedAt time.Time) (int, error) {
+	count := 0
+	for _, v := range m.verifications {
+		if v.UsedAt == nil && m.matchesFilter(v, filter) {
+			v.UsedAt = &usedAt
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockEmailVerificationRepository) matchesFilter(v *models.EmailVerification, filter models.EmailVerificationFilter) bool {
+	if len(filter.IDs) > 0 && !containsString(filter.IDs, v.ID) {
+		return false
+	}
+	if len(filter.UserIDs) > 0 && !containsString(filter.UserIDs, v.UserID) {
+		return false
+	}
+	if len(filter.TokenHashes) > 0 && !containsString(filter.TokenHashes, v.TokenHash) {
+		return false
+	}
+	if filter.CreatedAfter != nil && !v.CreatedAt.After(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.ActiveAt != nil && !v.IsActive(*filter.ActiveAt) {
+		return false
+	}
+	return true
+}
+
+func newVerifyingUsecase(notifier Notifier) *UserUsecase {
+	return NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithNotifier(notifier),
+		WithEmailVerification(newMockEmailVerificationRepository(), EmailVerificationSettings{
+			TokenTTL:            time.Hour,
+			RequireVerification: true,
+		}),
+	)
+}
+
+func TestUserUsecase_VerifyEmail(t *testing.T) {
+	notifier := newMockNotifier()
+	usecase := newVerifyingUsecase(notifier)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if user.Status != types.UserStatusInactive || user.IsEmailVerified() {
+		t.Fatalf("Create() status = %v, verified = %v, want inactive and unverified", user.Status, user.IsEmailVerified())
+	}
+
+	tokens := notifier.verificationTokens["user@example.com"]
+	if len(tokens) != 1 {
+		t.Fatalf("Create() sent %d verification tokens, want 1", len(tokens))
+	}
+
+	verified, err := usecase.VerifyEmail(ctx, tokens[0])
+	if err != nil {
+		t.Fatalf("VerifyEmail() unexpected error = %v", err)
+	}
+
+	if verified.Status != types.UserStatusActive || !verified.IsEmailVerified() {
+		t.Errorf("VerifyEmail() status = %v, verified = %v, want active and verified", verified.Status, verified.IsEmailVerified())
+	}
+
+	if _, err := usecase.VerifyEmail(ctx, tokens[0]); !errors.Is(err, types.ErrInvalidToken) {
+		t.Errorf("VerifyEmail() reused token error = %v, want %v", err, types.ErrInvalidToken)
+	}
+}
+
+func TestUserUsecase_Update_EmailChange(t *testing.T) {
+	notifier := newMockNotifier()
+	usecase := newVerifyingUsecase(notifier)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "old@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if _, err := usecase.VerifyEmail(ctx, notifier.verificationTokens["old@example.com"][0]); err != nil {
+		t.Fatalf("VerifyEmail() unexpected error = %v", err)
+	}
+
+	email := "new@example.com"
+
+	updated, err := usecase.Update(ctx, user.ID, models.UpdateUserInput{Email: &email})
+	if err != nil {
+		t.Fatalf("Update() unexpected error = %v", err)
+	}
+
+	if updated.Email != "old@example.com" {
+		t.Errorf("Update() email = %v, want unchanged until verification", updated.Email)
+	}
+
+	tokens := notifier.verificationTokens[email]
+	if len(tokens) != 1 {
+		t.Fatalf("Update() sent %d verification tokens to new email, want 1", len(tokens))
+	}
+
+	verified, err := usecase.VerifyEmail(ctx, tokens[0])
+	if err != nil {
+		t.Fatalf("VerifyEmail() unexpected error = %v", err)
+	}
+
+	if verified.Email != email || verified.EmailNormalized != email {
+		t.Errorf("VerifyEmail() email = %v, want %v", verified.Email, email)
+	}
+
+	if _, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: email, Password: "password123"}); err != nil {
+		t.Errorf("Authenticate() with new email unexpected error = %v", err)
+	}
+}
+
+func TestUserUsecase_Update_EmailChangeRateLimited(t *testing.T) {
+	notifier := newMockNotifier()
+	usecase := NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithNotifier(notifier),
+		WithEmailVerification(newMockEmailVerificationRepository(), EmailVerificationSettings{
+			TokenTTL:    time.Hour,
+			MaxRequests: 2,
+			Window:      time.Hour,
+		}),
+	)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	// Письмо при регистрации и первая смена email исчерпывают лимит.
+	for _, email := range []string{"first@example.com", "second@example.com"} {
+		if _, err := usecase.Update(ctx, user.ID, models.UpdateUserInput{Email: &email}); err != nil {
+			t.Fatalf("Update() email %s unexpected error = %v", email, err)
+		}
+	}
+
+	if len(notifier.verificationTokens["first@example.com"]) != 1 {
+		t.Errorf("Update() did not send verification to first@example.com")
+	}
+
+	if tokens := notifier.verificationTokens["second@example.com"]; len(tokens) != 0 {
+		t.Errorf("Update() sent %d verification tokens over the limit, want 0", len(tokens))
+	}
+}
+
+func TestUserUsecase_ResendEmailVerification(t *testing.T) {
+	notifier := newMockNotifier()
+	usecase := NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithNotifier(notifier),
+		WithEmailVerification(newMockEmailVerificationRepository(), EmailVerificationSettings{
+			TokenTTL:            time.Hour,
+			RequireVerification: true,
+			MaxRequests:         2,
+			Window:              time.Hour,
+		}),
+	)
+	ctx := context.Background()
+
+	if _, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"}); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if err := usecase.ResendEmailVerification(ctx, "USER@example.com"); err != nil {
+		t.Fatalf("ResendEmailVerification() unexpected error = %v", err)
+	}
+
+	if err := usecase.ResendEmailVerification(ctx, "user@example.com"); err != nil {
+		t.Fatalf("ResendEmailVerification() rate limited error = %v", err)
+	}
+
+	tokens := notifier.verificationTokens["user@example.com"]
+	if len(tokens) != 2 {
+		t.Fatalf("sent %d verification tokens, want 2", len(tokens))
+	}
+
+	if err := usecase.ResendEmailVerification(ctx, "unknown@example.com"); err != nil {
+		t.Errorf("ResendEmailVerification() unknown email error = %v, want nil", err)
+	}
+
+	if _, err := usecase.VerifyEmail(ctx, tokens[1]); err != nil {
+		t.Fatalf("VerifyEmail() resent token error = %v", err)
+	}
+}
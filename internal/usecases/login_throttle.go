@@ -0,0 +1,297 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/tenant"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// errLoginThrottleDisabled - защита от перебора не настроена через WithLoginThrottle.
+var errLoginThrottleDisabled = errors.New("login throttling is not configured")
+
+// Области счётчиков неудачных попыток входа, совпадают с метками метрик.
+const (
+	lockoutScopeUser = "user"
+	lockoutScopeIP   = "ip"
+)
+
+// LoginAttemptRepository - интерфейс хранилища счётчиков неудачных попыток входа.
+type LoginAttemptRepository interface {
+	Find(ctx context.Context, keys []string) ([]*models.LoginFailures, error)
+	// AddFailure атомарно увеличивает счётчик ключа и возвращает его новое
+	// состояние. Если последняя ошибка была раньше resetBefore, счёт начинается заново.
+	AddFailure(ctx context.Context, key string, at, resetBefore time.Time) (*models.LoginFailures, error)
+	// RemoveFailure атомарно уменьшает счётчики ключей на одну попытку,
+	// отменяя учтённую через AddFailure попытку, которая не была ошибкой.
+	RemoveFailure(ctx context.Context, keys []string) error
+	Reset(ctx context.Context, keys []string) error
+}
+
+// LoginThrottleLimits - пороги для одного вида счётчика.
+// FreeAttempts ошибок проходят без задержки, после LockAfter ошибок
+// вход блокируется на LockDuration. 0 в LockAfter отключает блокировку.
+type LoginThrottleLimits struct {
+	FreeAttempts int
+	LockAfter    int
+}
+
+// LoginThrottleSettings - настройки защиты от перебора паролей.
+// После FreeAttempts ошибок каждая следующая попытка возможна не раньше
+// BaseDelay * 2^n после предыдущей ошибки, но не позже MaxDelay.
+// Счётчик сбрасывается, если ошибок не было дольше Window.
+type LoginThrottleSettings struct {
+	Window       time.Duration
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	LockDuration time.Duration
+	User         LoginThrottleLimits
+	IP           LoginThrottleLimits
+}
+
+// loginKey - ключ счётчика неудачных попыток и его пороги.
+type loginKey struct {
+	key    string
+	scope  string
+	limits LoginThrottleLimits
+}
+
+// loginKeys возвращает счётчики для попытки входа. Пользователь
+// определяется каноничным email, а не ID, чтобы несуществующие адреса
+// блокировались так же и не раскрывали наличие аккаунта.
+func (m *UserUsecase) loginKeys(ctx context.Context, email string, client models.ClientInfo) []loginKey {
+	keys := []loginKey{{key: userLoginKey(ctx, email), scope: lockoutScopeUser, limits: m.throttleSettings.User}}
+
+	if client.IP != "" {
+		keys = append(keys, loginKey{key: "ip:" + client.IP, scope: lockoutScopeIP, limits: m.throttleSettings.IP})
+	}
+
+	return keys
+}
+
+// userLoginKey возвращает ключ счётчика пользователя по каноничному email.
+// Один адрес в разных арендаторах - разные пользователи со своими счётчиками.
+func userLoginKey(ctx context.Context, email string) string {
+	if tenantID, ok := tenant.FromContext(ctx); ok {
+		return "email:" + tenantID + ":" + email
+	}
+
+	return "email:" + email
+}
+
+// loginAttempt - попытка входа, заранее учтённая в счётчиках как неудачная.
+// reserved - состояние счётчиков keys сразу после резервирования.
+type loginAttempt struct {
+	keys     []loginKey
+	reserved []*models.LoginFailures
+}
+
+// reserveLoginAttempt проверяет, что попытка входа сейчас разрешена, и до
+// проверки пароля или кода атомарно учитывает её во всех счётчиках как
+// неудачную. Иначе параллельные попытки проходили бы проверку раньше, чем
+// учтена хотя бы одна ошибка, и обходили бы задержки и блокировку.
+// Если счётчик успел вырасти из-за параллельной попытки, эта попытка
+// проверяется заново так, будто предыдущая ошибка случилась только что,
+// и при отказе остаётся учтённой. Исход разрешённой попытки сообщается
+// через failLoginAttempt, releaseLoginAttempt или succeedLoginAttempt.
+func (m *UserUsecase) reserveLoginAttempt(ctx context.Context, email string, client models.ClientInfo) (*loginAttempt, error) {
+	attempt := &loginAttempt{}
+
+	if m.attempts == nil {
+		return attempt, nil
+	}
+
+	keys := m.loginKeys(ctx, email, client)
+
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = k.key
+	}
+
+	failures, err := m.attempts.Find(ctx, values)
+	if err != nil {
+		return nil, fmt.Errorf("find login failures: %w", err)
+	}
+
+	now := time.Now()
+	resetBefore := now.Add(-m.throttleSettings.Window)
+
+	seen := make(map[string]*models.LoginFailures, len(failures))
+	for _, f := range failures {
+		seen[f.Key] = f
+	}
+
+	var throttled *types.LoginThrottledError
+
+	check := func(f *models.LoginFailures, limits LoginThrottleLimits) {
+		until, locked := m.blockedUntil(f, limits)
+		if wait := until.Sub(now); wait > 0 && (throttled == nil || wait > throttled.RetryAfter) {
+			throttled = &types.LoginThrottledError{RetryAfter: wait, Locked: locked}
+		}
+	}
+
+	for _, k := range keys {
+		if f, ok := seen[k.key]; ok {
+			check(f, k.limits)
+		}
+	}
+
+	if throttled != nil {
+		return nil, throttled
+	}
+
+	for _, k := range keys {
+		f, err := m.attempts.AddFailure(ctx, k.key, now, resetBefore)
+		if err != nil {
+			return nil, fmt.Errorf("add login failure: %w", err)
+		}
+
+		attempt.keys = append(attempt.keys, k)
+		attempt.reserved = append(attempt.reserved, f)
+
+		count := 0
+		if prev, ok := seen[k.key]; ok && !prev.LastFailureAt.Before(resetBefore) {
+			count = prev.Count
+		}
+
+		if f.Count-1 != count {
+			check(&models.LoginFailures{Key: k.key, Count: f.Count - 1, LastFailureAt: now}, k.limits)
+		}
+	}
+
+	if throttled != nil {
+		m.logLockouts(ctx, attempt)
+		return nil, throttled
+	}
+
+	return attempt, nil
+}
+
+// blockedUntil возвращает момент, до которого вход по счётчику запрещён,
+// и признак блокировки.
+func (m *UserUsecase) blockedUntil(f *models.LoginFailures, limits LoginThrottleLimits) (time.Time, bool) {
+	if limits.LockAfter > 0 && f.Count >= limits.LockAfter {
+		return f.LastFailureAt.Add(m.throttleSettings.LockDuration), true
+	}
+
+	if f.Count < limits.FreeAttempts {
+		return time.Time{}, false
+	}
+
+	delay := m.throttleSettings.BaseDelay
+	for i := limits.FreeAttempts; i < f.Count && delay < m.throttleSettings.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return f.LastFailureAt.Add(min(delay, m.throttleSettings.MaxDelay)), false
+}
+
+// failLoginAttempt оставляет зарезервированную попытку учтённой как неудачную.
+func (m *UserUsecase) failLoginAttempt(ctx context.Context, attempt *loginAttempt) {
+	m.metrics.IncLoginFailures()
+	m.logLockouts(ctx, attempt)
+}
+
+// logLockouts сообщает о блокировке по счётчикам, которые попытка довела до LockAfter.
+func (m *UserUsecase) logLockouts(ctx context.Context, attempt *loginAttempt) {
+	for i, k := range attempt.keys {
+		if k.limits.LockAfter > 0 && attempt.reserved[i].Count == k.limits.LockAfter {
+			slog.WarnContext(ctx, "login locked",
+				slog.String("scope", k.scope),
+				slog.Duration("duration", m.throttleSettings.LockDuration),
+			)
+			m.metrics.IncLoginLockouts(k.scope)
+		}
+	}
+}
+
+// releaseLoginAttempt снимает зарезервированную попытку со всех счётчиков,
+// не сбрасывая их: попытка не была ошибкой, но и вход ещё не завершён.
+func (m *UserUsecase) releaseLoginAttempt(ctx context.Context, attempt *loginAttempt) error {
+	return m.removeLoginFailures(ctx, attempt, "")
+}
+
+// abortLoginAttempt снимает попытку, прерванную внутренней ошибкой err,
+// и возвращает err: сбой хранилища не должен считаться неверным паролем.
+func (m *UserUsecase) abortLoginAttempt(ctx context.Context, attempt *loginAttempt, err error) error {
+	if releaseErr := m.releaseLoginAttempt(ctx, attempt); releaseErr != nil {
+		slog.WarnContext(ctx, "failed to release login attempt", slog.Any("error", releaseErr))
+	}
+
+	return err
+}
+
+// succeedLoginAttempt сбрасывает счётчик пользователя после успешного входа
+// и снимает зарезервированную попытку с остальных счётчиков. Счётчик IP
+// не сбрасывается, иначе вход в свой аккаунт позволял бы продолжать
+// перебор чужих паролей с того же адреса.
+func (m *UserUsecase) succeedLoginAttempt(ctx context.Context, attempt *loginAttempt) error {
+	return m.removeLoginFailures(ctx, attempt, lockoutScopeUser)
+}
+
+// removeLoginFailures сбрасывает счётчики области resetScope и снимает
+// зарезервированную попытку с остальных счётчиков.
+func (m *UserUsecase) removeLoginFailures(ctx context.Context, attempt *loginAttempt, resetScope string) error {
+	var reset, remove []string
+
+	for _, k := range attempt.keys {
+		if k.scope == resetScope {
+			reset = append(reset, k.key)
+		} else {
+			remove = append(remove, k.key)
+		}
+	}
+
+	if len(reset) > 0 {
+		if err := m.attempts.Reset(ctx, reset); err != nil {
+			return fmt.Errorf("reset login failures: %w", err)
+		}
+	}
+
+	if len(remove) > 0 {
+		if err := m.attempts.RemoveFailure(ctx, remove); err != nil {
+			return fmt.Errorf("remove login failure: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// resetLoginFailures сбрасывает счётчик пользователя.
+func (m *UserUsecase) resetLoginFailures(ctx context.Context, email string) error {
+	if m.attempts == nil {
+		return nil
+	}
+
+	if err := m.attempts.Reset(ctx, []string{userLoginKey(ctx, email)}); err != nil {
+		return fmt.Errorf("reset login failures: %w", err)
+	}
+
+	return nil
+}
+
+// UnlockUser снимает задержки и блокировку входа пользователя,
+// наступившие из-за неудачных попыток.
+func (m *UserUsecase) UnlockUser(ctx context.Context, id string) (*models.User, error) {
+	if m.attempts == nil {
+		return nil, errLoginThrottleDisabled
+	}
+
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{id}})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.resetLoginFailures(ctx, user.EmailNormalized); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user login unlocked", slog.String("user_id", user.ID))
+
+	return user, nil
+}
@@ -0,0 +1,322 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+type mockLoginAttemptRepository struct {
+	failures map[string]*models.LoginFailures
+}
+
+func newMockLoginAttemptRepository() *mockLoginAttemptRepository {
+	return &mockLoginAttemptRepository{failures: make(map[string]*models.LoginFailures)}
+}
+
+func (m *mockLoginAttemptRepository) Find(ctx context.Context, keys []string) ([]*models.LoginFailures, error) {
+	var result []*models.LoginFailures
+	for _, key := range keys {
+		if f, ok := m.failures[key]; ok {
+			found := *f
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockLoginAttemptRepository) AddFailure(ctx context.Context, key string, at, resetBefore time.Time) (*models.LoginFailures, error) {
+	f, ok := m.failures[key]
+	if !ok || f.LastFailureAt.Before(resetBefore) {
+		f = &models.LoginFailures{Key: key}
+		m.failures[key] = f
+	}
+	f.Count++
+	f.LastFailureAt = at
+	found := *f
+	return &found, nil
+}
+
+func (m *mockLoginAttemptRepository) RemoveFailure(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if f, ok := m.failures[key]; ok && f.Count > 0 {
+			f.Count--
+		}
+	}
+	return nil
+}
+
+func (m *mockLoginAttemptRepository) Reset(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		delete(m.failures, key)
+	}
+	return nil
+}
+
+// rewind сдвигает время последних ошибок в прошлое вместо ожидания.
+func (m *mockLoginAttemptRepository) rewind(d time.Duration) {
+	for _, f := range m.failures {
+		f.LastFailureAt = f.LastFailureAt.Add(-d)
+	}
+}
+
+func newThrottleUsecase(attempts *mockLoginAttemptRepository) *UserUsecase {
+	return NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithLoginThrottle(attempts, LoginThrottleSettings{
+			Window:       24 * time.Hour,
+			BaseDelay:    time.Minute,
+			MaxDelay:     10 * time.Minute,
+			LockDuration: time.Hour,
+			User:         LoginThrottleLimits{FreeAttempts: 2, LockAfter: 4},
+			IP:           LoginThrottleLimits{FreeAttempts: 3},
+		}),
+	)
+}
+
+func TestUserUsecase_LoginThrottle(t *testing.T) {
+	attempts := newMockLoginAttemptRepository()
+	usecase := newThrottleUsecase(attempts)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	wrong := models.AuthenticateInput{Email: user.Email, Password: "wrong"}
+	right := models.AuthenticateInput{Email: user.Email, Password: "password123"}
+
+	fail := func() {
+		t.Helper()
+
+		if _, err := usecase.Authenticate(ctx, wrong); !errors.Is(err, types.ErrInvalidCredentials) {
+			t.Fatalf("Authenticate() wrong password error = %v, want %v", err, types.ErrInvalidCredentials)
+		}
+	}
+
+	throttled := func(input models.AuthenticateInput) *types.LoginThrottledError {
+		t.Helper()
+
+		_, err := usecase.Authenticate(ctx, input)
+
+		var throttledErr *types.LoginThrottledError
+		if !errors.As(err, &throttledErr) || !errors.Is(err, types.ErrTooManyAttempts) {
+			t.Fatalf("Authenticate() error = %v, want %T", err, throttledErr)
+		}
+
+		return throttledErr
+	}
+
+	// Успешный вход сбрасывает счётчик пользователя.
+	fail()
+	if _, err := usecase.Authenticate(ctx, right); err != nil {
+		t.Fatalf("Authenticate() unexpected error = %v", err)
+	}
+
+	fail()
+	fail()
+
+	// После бесплатных попыток даже верный пароль не проверяется до истечения задержки.
+	if e := throttled(right); e.Locked || e.RetryAfter <= 0 || e.RetryAfter > time.Minute {
+		t.Errorf("throttled after free attempts = %+v, want delay up to 1m", e)
+	}
+
+	attempts.rewind(time.Minute)
+	fail()
+
+	if e := throttled(wrong); e.Locked || e.RetryAfter <= time.Minute || e.RetryAfter > 2*time.Minute {
+		t.Errorf("throttled after 3 failures = %+v, want doubled delay", e)
+	}
+
+	attempts.rewind(2 * time.Minute)
+	fail()
+
+	if e := throttled(right); !e.Locked || e.RetryAfter <= 50*time.Minute {
+		t.Errorf("throttled after lock = %+v, want lock for 1h", e)
+	}
+
+	// Блокировка снимается сама по истечении срока.
+	attempts.rewind(time.Hour)
+	if _, err := usecase.Authenticate(ctx, right); err != nil {
+		t.Errorf("Authenticate() after lock expired error = %v", err)
+	}
+}
+
+// staleLoginAttemptRepository отдаёт из Find снимок счётчиков, как если бы
+// все параллельные попытки прочитали их до того, как любая была учтена.
+type staleLoginAttemptRepository struct {
+	*mockLoginAttemptRepository
+	snapshot []*models.LoginFailures
+}
+
+func (m *staleLoginAttemptRepository) Find(ctx context.Context, keys []string) ([]*models.LoginFailures, error) {
+	return m.snapshot, nil
+}
+
+func TestUserUsecase_LoginThrottleParallel(t *testing.T) {
+	attempts := newMockLoginAttemptRepository()
+	usecase := newThrottleUsecase(attempts)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	wrong := models.AuthenticateInput{Email: user.Email, Password: "wrong", Client: models.ClientInfo{IP: "10.0.0.1"}}
+	if _, err := usecase.Authenticate(ctx, wrong); !errors.Is(err, types.ErrInvalidCredentials) {
+		t.Fatalf("Authenticate() wrong password error = %v, want %v", err, types.ErrInvalidCredentials)
+	}
+
+	snapshot, _ := attempts.Find(ctx, []string{userLoginKey(ctx, user.EmailNormalized), "ip:10.0.0.1"})
+	usecase.attempts = &staleLoginAttemptRepository{mockLoginAttemptRepository: attempts, snapshot: snapshot}
+
+	// Все попытки видят одну ошибку из двух бесплатных, но проверяется
+	// пароль только в первой: остальные увидели рост счётчика при резервировании.
+	var failed, throttled int
+
+	for i := 0; i < 5; i++ {
+		_, err := usecase.Authenticate(ctx, wrong)
+
+		var throttledErr *types.LoginThrottledError
+		switch {
+		case errors.Is(err, types.ErrInvalidCredentials):
+			failed++
+		case errors.As(err, &throttledErr):
+			throttled++
+		default:
+			t.Fatalf("Authenticate() parallel attempt error = %v", err)
+		}
+	}
+
+	if failed != 1 || throttled != 4 {
+		t.Errorf("parallel attempts: %d checked, %d throttled, want 1 and 4", failed, throttled)
+	}
+
+	// Отклонённые попытки тоже учтены, и пользователь заблокирован.
+	usecase.attempts = attempts
+
+	var throttledErr *types.LoginThrottledError
+	if _, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"}); !errors.As(err, &throttledErr) || !throttledErr.Locked {
+		t.Errorf("Authenticate() after parallel attempts error = %v, want lockout", err)
+	}
+}
+
+// failingUserRepository отвечает на поиск пользователей ошибкой хранилища.
+type failingUserRepository struct {
+	*mockRepository
+}
+
+func (m *failingUserRepository) Find(ctx context.Context, filter models.UserFilter, pagination *models.Pagination) ([]*models.User, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestUserUsecase_LoginThrottleRepositoryError(t *testing.T) {
+	attempts := newMockLoginAttemptRepository()
+	usecase := newThrottleUsecase(attempts)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	repo := usecase.repo.(*mockRepository)
+	usecase.repo = &failingUserRepository{mockRepository: repo}
+
+	right := models.AuthenticateInput{Email: user.Email, Password: "password123", Client: models.ClientInfo{IP: "10.0.0.1"}}
+
+	// Сбои хранилища не считаются неверными паролями и не блокируют вход.
+	for i := 0; i < 5; i++ {
+		if _, err := usecase.Authenticate(ctx, right); err == nil || errors.Is(err, types.ErrTooManyAttempts) {
+			t.Fatalf("Authenticate() with failing repository error = %v, want storage error", err)
+		}
+	}
+
+	usecase.repo = repo
+
+	if _, err := usecase.Authenticate(ctx, right); err != nil {
+		t.Errorf("Authenticate() after repository recovered error = %v", err)
+	}
+
+	for key, f := range attempts.failures {
+		if f.Count != 0 {
+			t.Errorf("counter %s = %d after storage errors, want 0", key, f.Count)
+		}
+	}
+}
+
+func TestUserUsecase_LoginThrottleByIP(t *testing.T) {
+	attempts := newMockLoginAttemptRepository()
+	usecase := newThrottleUsecase(attempts)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	attacker := models.ClientInfo{IP: "10.0.0.1"}
+
+	// Перебор по разным адресам, включая несуществующие, учитывается для IP.
+	for _, email := range []string{"a@example.com", "b@example.com", user.Email} {
+		_, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: email, Password: "wrong", Client: attacker})
+		if !errors.Is(err, types.ErrInvalidCredentials) {
+			t.Fatalf("Authenticate(%s) error = %v, want %v", email, err, types.ErrInvalidCredentials)
+		}
+	}
+
+	input := models.AuthenticateInput{Email: user.Email, Password: "password123", Client: attacker}
+	if _, err := usecase.Authenticate(ctx, input); !errors.Is(err, types.ErrTooManyAttempts) {
+		t.Errorf("Authenticate() from throttled IP error = %v, want %v", err, types.ErrTooManyAttempts)
+	}
+
+	input.Client = models.ClientInfo{IP: "10.0.0.2"}
+	if _, err := usecase.Authenticate(ctx, input); err != nil {
+		t.Errorf("Authenticate() from another IP error = %v", err)
+	}
+}
+
+func TestUserUsecase_UnlockUser(t *testing.T) {
+	attempts := newMockLoginAttemptRepository()
+	usecase := newThrottleUsecase(attempts)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	attempts.failures[userLoginKey(ctx, user.EmailNormalized)] = &models.LoginFailures{
+		Key:           userLoginKey(ctx, user.EmailNormalized),
+		Count:         4,
+		LastFailureAt: time.Now(),
+	}
+
+	input := models.AuthenticateInput{Email: user.Email, Password: "password123"}
+	if _, err := usecase.Authenticate(ctx, input); !errors.Is(err, types.ErrTooManyAttempts) {
+		t.Fatalf("Authenticate() locked error = %v, want %v", err, types.ErrTooManyAttempts)
+	}
+
+	if _, err := usecase.UnlockUser(ctx, user.ID); err != nil {
+		t.Fatalf("UnlockUser() unexpected error = %v", err)
+	}
+
+	if _, err := usecase.Authenticate(ctx, input); err != nil {
+		t.Errorf("Authenticate() after unlock error = %v", err)
+	}
+
+	if _, err := usecase.UnlockUser(ctx, "missing"); !errors.Is(err, types.ErrUserNotFound) {
+		t.Errorf("UnlockUser() missing user error = %v, want %v", err, types.ErrUserNotFound)
+	}
+
+	if _, err := NewUserUsecase(newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{}).
+		UnlockUser(ctx, user.ID); !errors.Is(err, errLoginThrottleDisabled) {
+		t.Errorf("UnlockUser() without throttle error = %v, want %v", err, errLoginThrottleDisabled)
+	}
+}
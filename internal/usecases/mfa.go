@@ -0,0 +1,427 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// errMFADisabled - двухфакторная аутентификация не настроена через WithMFA.
+var errMFADisabled = errors.New("two-factor authentication is not configured")
+
+// recoveryCodeLength - длина кода восстановления без разделителя.
+const recoveryCodeLength = 10
+
+// recoveryCodeEncoding - алфавит кодов восстановления: base32 в нижнем регистре.
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// TOTPGenerator - интерфейс одноразовых паролей по времени (RFC 6238).
+// Validate возвращает временной шаг, которому соответствует код.
+type TOTPGenerator interface {
+	GenerateSecret() (string, error)
+	URI(secret, account string) string
+	Validate(secret, code string, at time.Time) (int64, bool)
+}
+
+// SecretCipher - интерфейс шифрования секретов перед сохранением в хранилище.
+type SecretCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+// MFAChallengeRepository - интерфейс репозитория токенов второго шага входа.
+type MFAChallengeRepository interface {
+	Create(ctx context.Context, challenge *models.MFAChallenge) error
+	Find(ctx context.Context, filter models.MFAChallengeFilter) ([]*models.MFAChallenge, error)
+	// AddAttempt увеличивает счётчик неверных кодов и возвращает новое значение.
+	AddAttempt(ctx context.Context, id string) (int, error)
+	// Use помечает неиспользованные токены по фильтру использованными.
+	// Возвращает количество помеченных.
+	Use(ctx context.Context, filter models.MFAChallengeFilter, usedAt time.Time) (int, error)
+}
+
+// MFASettings - настройки двухфакторной аутентификации.
+type MFASettings struct {
+	ChallengeTTL time.Duration
+	// MaxAttempts - сколько неверных кодов можно ввести по одному токену
+	// второго шага, после этого вход начинается заново.
+	MaxAttempts int
+	// RecoveryCodes - сколько кодов восстановления выдаётся при подключении.
+	RecoveryCodes int
+}
+
+// EnrollTOTP начинает подключение TOTP: выпускает новый секрет и сохраняет
+// его зашифрованным. MFA включается только после ConfirmTOTP, повторный
+// вызов до подтверждения заменяет секрет.
+func (m *UserUsecase) EnrollTOTP(ctx context.Context, userID string) (*models.TOTPEnrollment, error) {
+	if m.totp == nil {
+		return nil, errMFADisabled
+	}
+
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{userID}})
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsMFAEnabled() {
+		return nil, types.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := m.totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := m.cipher.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt totp secret: %w", err)
+	}
+
+	user.TOTPSecret = encrypted
+	user.TOTPLastStep = 0
+	user.UpdatedAt = time.Now()
+
+	if err := m.repo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	return &models.TOTPEnrollment{
+		Secret: secret,
+		URI:    m.totp.URI(secret, user.Email),
+	}, nil
+}
+
+// ConfirmTOTP включает MFA по первому коду из приложения-аутентификатора
+// и возвращает коды восстановления. Коды показываются один раз, хранятся только их хэши.
+func (m *UserUsecase) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	if m.totp == nil {
+		return nil, errMFADisabled
+	}
+
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{userID}})
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsMFAEnabled() {
+		return nil, types.ErrMFAAlreadyEnabled
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, types.ErrMFANotEnabled
+	}
+
+	now := time.Now()
+
+	step, ok, err := m.checkTOTP(user, code, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, types.ErrInvalidMFACode
+	}
+
+	codes, hashes, err := m.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPEnabledAt = &now
+	user.TOTPLastStep = step
+	user.RecoveryCodeHashes = hashes
+	user.UpdatedAt = now
+
+	if err := m.repo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	slog.InfoContext(ctx, "mfa enabled", slog.String("user_id", user.ID))
+
+	return codes, nil
+}
+
+// DisableTOTP выключает MFA. Требуется действующий код из приложения
+// или код восстановления, чтобы украденной сессии было недостаточно.
+// Неверные коды учитываются в счётчике пользователя защиты от перебора,
+// иначе с украденной сессией коды можно было бы подбирать без ограничений.
+func (m *UserUsecase) DisableTOTP(ctx context.Context, userID, code string) error {
+	if m.totp == nil {
+		return errMFADisabled
+	}
+
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{userID}})
+	if err != nil {
+		return err
+	}
+
+	if !user.IsMFAEnabled() {
+		return types.ErrMFANotEnabled
+	}
+
+	attempt, err := m.reserveLoginAttempt(ctx, user.EmailNormalized, models.ClientInfo{})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	ok, err := m.checkMFACode(user, code, now)
+	if err != nil {
+		return m.abortLoginAttempt(ctx, attempt, err)
+	}
+
+	if !ok {
+		slog.InfoContext(ctx, "mfa disable failed", slog.String("user_id", user.ID))
+		m.failLoginAttempt(ctx, attempt)
+		return types.ErrInvalidMFACode
+	}
+
+	if err := m.releaseLoginAttempt(ctx, attempt); err != nil {
+		return err
+	}
+
+	user.TOTPSecret = ""
+	user.TOTPEnabledAt = nil
+	user.TOTPLastStep = 0
+	user.RecoveryCodeHashes = nil
+	user.UpdatedAt = now
+
+	if err := m.repo.Update(ctx, user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	slog.InfoContext(ctx, "mfa disabled", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+// startMFAChallenge выпускает токен второго шага входа вместо сессии.
+func (m *UserUsecase) startMFAChallenge(ctx context.Context, user *models.User) (*models.AuthResult, error) {
+	if m.challenges == nil {
+		return nil, errMFADisabled
+	}
+
+	token, hash, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	challenge := &models.MFAChallenge{
+		ID:        m.idGen.Generate(),
+		UserID:    user.ID,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(m.mfaSettings.ChallengeTTL),
+	}
+
+	if err := m.challenges.Create(ctx, challenge); err != nil {
+		return nil, fmt.Errorf("create mfa challenge: %w", err)
+	}
+
+	return &models.AuthResult{
+		User: user,
+		MFAChallenge: &models.MFAChallengeToken{
+			Token:     token,
+			ExpiresAt: challenge.ExpiresAt,
+		},
+	}, nil
+}
+
+// VerifyMFA завершает вход пользователя с MFA: проверяет код по токену
+// второго шага, открывает сессию и выпускает токены. Токен одноразовый
+// и перестаёт действовать после MaxAttempts неверных кодов. Неверные коды
+// учитываются в защите от перебора так же, как неверные пароли.
+func (m *UserUsecase) VerifyMFA(ctx context.Context, input models.VerifyMFAInput) (*models.AuthResult, error) {
+	if m.challenges == nil {
+		return nil, errMFADisabled
+	}
+
+	now := time.Now()
+
+	challenges, err := m.challenges.Find(ctx, models.MFAChallengeFilter{
+		TokenHashes: []string{hashSecretToken(input.Token)},
+		ActiveAt:    &now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find mfa challenge: %w", err)
+	}
+
+	if len(challenges) == 0 {
+		return nil, types.ErrInvalidToken
+	}
+
+	challenge := challenges[0]
+
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{challenge.UserID}})
+	if err != nil {
+		if types.IsNotFound(err) {
+			return nil, types.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if err := checkCanLogin(user); err != nil {
+		return nil, err
+	}
+
+	if !user.IsMFAEnabled() {
+		return nil, types.ErrInvalidToken
+	}
+
+	attempt, err := m.reserveLoginAttempt(ctx, user.EmailNormalized, input.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := m.checkMFACode(user, input.Code, now)
+	if err != nil {
+		return nil, m.abortLoginAttempt(ctx, attempt, err)
+	}
+
+	if !ok {
+		m.failLoginAttempt(ctx, attempt)
+		return nil, m.failMFAChallenge(ctx, challenge, now)
+	}
+
+	// Токен помечается использованным условно, поэтому из двух
+	// параллельных запросов с одним токеном пройдёт только один.
+	used, err := m.challenges.Use(ctx, models.MFAChallengeFilter{IDs: []string{challenge.ID}, ActiveAt: &now}, now)
+	if err != nil {
+		return nil, m.abortLoginAttempt(ctx, attempt, fmt.Errorf("use mfa challenge: %w", err))
+	}
+
+	if used == 0 {
+		if err := m.releaseLoginAttempt(ctx, attempt); err != nil {
+			return nil, err
+		}
+		return nil, types.ErrInvalidToken
+	}
+
+	// Сохраняем шаг принятого кода или удаляем использованный код
+	// восстановления. Optimistic locking не даст принять один код дважды.
+	user.UpdatedAt = now
+	if err := m.repo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	if err := m.succeedLoginAttempt(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	return m.openSession(ctx, user, input.Client)
+}
+
+// failMFAChallenge учитывает неверный код и отзывает токен второго шага
+// после MaxAttempts попыток. Возвращает ошибку для клиента.
+func (m *UserUsecase) failMFAChallenge(ctx context.Context, challenge *models.MFAChallenge, now time.Time) error {
+	slog.InfoContext(ctx, "mfa verification failed", slog.String("user_id", challenge.UserID))
+
+	attempts, err := m.challenges.AddAttempt(ctx, challenge.ID)
+	if err != nil {
+		return fmt.Errorf("add mfa attempt: %w", err)
+	}
+
+	if m.mfaSettings.MaxAttempts > 0 && attempts >= m.mfaSettings.MaxAttempts {
+		if _, err := m.challenges.Use(ctx, models.MFAChallengeFilter{IDs: []string{challenge.ID}}, now); err != nil {
+			return fmt.Errorf("use mfa challenge: %w", err)
+		}
+	}
+
+	return types.ErrInvalidMFACode
+}
+
+// checkMFACode проверяет код из приложения или код восстановления.
+// При успехе обновляет пользователя (шаг кода или оставшиеся коды
+// восстановления), сохранить его должен вызывающий.
+func (m *UserUsecase) checkMFACode(user *models.User, code string, now time.Time) (bool, error) {
+	step, ok, err := m.checkTOTP(user, code, now)
+	if err != nil {
+		return false, err
+	}
+
+	if ok {
+		user.TOTPLastStep = step
+		return true, nil
+	}
+
+	// Коды восстановления хэшируются тем же медленным hasher, что и пароли,
+	// поэтому сравниваем только строки подходящего формата.
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) != recoveryCodeLength {
+		return false, nil
+	}
+
+	for i, hash := range user.RecoveryCodeHashes {
+		if !m.hasher.Compare(hash, normalized) {
+			continue
+		}
+
+		// Новый слайс, чтобы не менять массив, разделяемый с хранилищем.
+		remaining := make([]string, 0, len(user.RecoveryCodeHashes)-1)
+		remaining = append(remaining, user.RecoveryCodeHashes[:i]...)
+		remaining = append(remaining, user.RecoveryCodeHashes[i+1:]...)
+		user.RecoveryCodeHashes = remaining
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// checkTOTP проверяет код из приложения. Коды шага, не новее уже
+// принятого, отклоняются, чтобы перехваченный код нельзя было повторить.
+func (m *UserUsecase) checkTOTP(user *models.User, code string, now time.Time) (int64, bool, error) {
+	secret, err := m.cipher.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return 0, false, fmt.Errorf("decrypt totp secret: %w", err)
+	}
+
+	step, ok := m.totp.Validate(secret, strings.TrimSpace(code), now)
+	if !ok || step <= user.TOTPLastStep {
+		return 0, false, nil
+	}
+
+	return step, true, nil
+}
+
+// newRecoveryCodes генерирует коды восстановления и их хэши для хранения.
+// Коды выдаются в виде "xxxxx-xxxxx".
+func (m *UserUsecase) newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, m.mfaSettings.RecoveryCodes)
+	hashes := make([]string, m.mfaSettings.RecoveryCodes)
+
+	raw := make([]byte, recoveryCodeEncoding.DecodedLen(recoveryCodeLength))
+
+	for i := range codes {
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("generate recovery code: %w", err)
+		}
+
+		code := recoveryCodeEncoding.EncodeToString(raw)[:recoveryCodeLength]
+
+		hash, err := m.hasher.Hash(code)
+		if err != nil {
+			return nil, nil, fmt.Errorf("hash recovery code: %w", err)
+		}
+
+		codes[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+		hashes[i] = hash
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode приводит введённый код восстановления к хранимой форме.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
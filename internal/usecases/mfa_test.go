@@ -0,0 +1,384 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// mockTOTP принимает в качестве кода номер временного шага.
+type mockTOTP struct{}
+
+func (m *mockTOTP) GenerateSecret() (string, error) {
+	return "SECRET", nil
+}
+
+func (m *mockTOTP) URI(secret, account string) string {
+	return "otpauth://totp/test:" + account + "?secret=" + secret
+}
+
+func (m *mockTOTP) Validate(secret, code string, at time.Time) (int64, bool) {
+	step, err := strconv.ParseInt(code, 10, 64)
+	if err != nil || secret != "SECRET" {
+		return 0, false
+	}
+
+	current := totpStep(at)
+	return step, step >= current-1 && step <= current+1
+}
+
+func totpStep(at time.Time) int64 {
+	return at.Unix() / 30
+}
+
+func totpCode(step int64) string {
+	return strconv.FormatInt(step, 10)
+}
+
+type mockCipher struct{}
+
+func (m *mockCipher) Encrypt(plaintext string) (string, error) {
+	return "enc:" + plaintext, nil
+}
+
+func (m *mockCipher) Decrypt(ciphertext string) (string, error) {
+	return strings.TrimPrefix(ciphertext, "enc:"), nil
+}
+
+// failingCipher не может расшифровать секрет TOTP.
+type failingCipher struct {
+	mockCipher
+}
+
+func (m *failingCipher) Decrypt(ciphertext string) (string, error) {
+	return "", errors.New("cipher: message authentication failed")
+}
+
+type mockMFAChallengeRepository struct {
+	challenges map[string]*models.MFAChallenge
+}
+
+func newMockMFAChallengeRepository() *mockMFAChallengeRepository {
+	return &mockMFAChallengeRepository{challenges: make(map[string]*models.MFAChallenge)}
+}
+
+func (m *mockMFAChallengeRepository) Create(ctx context.Context, challenge *models.MFAChallenge) error {
+	stored := *challenge
+	m.challenges[challenge.ID] = &stored
+	return nil
+}
+
+func (m *mockMFAChallengeRepository) Find(ctx context.Context, filter models.MFAChallengeFilter) ([]*models.MFAChallenge, error) {
+	var result []*models.MFAChallenge
+	for _, c := range m.challenges {
+		if m.matchesFilter(c, filter) {
+			found := *c
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMFAChallengeRepository) AddAttempt(ctx context.Context, id string) (int, error) {
+	c, ok := m.challenges[id]
+	if !ok {
+		return 0, nil
+	}
+	c.Attempts++
+	return c.Attempts, nil
+}
+
+func (m *mockMFAChallengeRepository) Use(ctx context.Context, filter models.MFAChallengeFilter, usedAt time.Time) (int, error) {
+	count := 0
+	for _, c := range m.challenges {
+		if c.UsedAt == nil && m.matchesFilter(c, filter) {
+			c.UsedAt = &usedAt
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockMFAChallengeRepository) matchesFilter(c *models.MFAChallenge, filter models.MFAChallengeFilter) bool {
+	if len(filter.IDs) > 0 && !containsString(filter.IDs, c.ID) {
+		return false
+	}
+	if len(filter.UserIDs) > 0 && !containsString(filter.UserIDs, c.UserID) {
+		return false
+	}
+	if len(filter.TokenHashes) > 0 && !containsString(filter.TokenHashes, c.TokenHash) {
+		return false
+	}
+	if filter.ActiveAt != nil && !c.IsActive(*filter.ActiveAt) {
+		return false
+	}
+	return true
+}
+
+func newMFAUsecase() *UserUsecase {
+	return NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithMFA(&mockTOTP{}, &mockCipher{}, newMockMFAChallengeRepository(), MFASettings{
+			ChallengeTTL:  time.Minute,
+			MaxAttempts:   3,
+			RecoveryCodes: 3,
+		}),
+	)
+}
+
+func TestUserUsecase_EnrollTOTP(t *testing.T) {
+	usecase := newMFAUsecase()
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if _, err := usecase.ConfirmTOTP(ctx, user.ID, totpCode(totpStep(time.Now()))); !errors.Is(err, types.ErrMFANotEnabled) {
+		t.Errorf("ConfirmTOTP() before enroll error = %v, want %v", err, types.ErrMFANotEnabled)
+	}
+
+	enrollment, err := usecase.EnrollTOTP(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("EnrollTOTP() unexpected error = %v", err)
+	}
+
+	if enrollment.Secret != "SECRET" || !strings.Contains(enrollment.URI, user.Email) {
+		t.Errorf("EnrollTOTP() = %+v, want secret and URI for %s", enrollment, user.Email)
+	}
+
+	stored, _ := usecase.GetByID(ctx, user.ID)
+	if stored.TOTPSecret != "enc:SECRET" {
+		t.Errorf("stored secret = %q, want encrypted", stored.TOTPSecret)
+	}
+
+	if stored.IsMFAEnabled() {
+		t.Error("MFA enabled before confirmation")
+	}
+
+	// До подтверждения вход остаётся одношаговым.
+	login, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"})
+	if err != nil || login.MFAChallenge != nil {
+		t.Fatalf("Authenticate() before confirmation = %+v, %v, want tokens", login, err)
+	}
+
+	if _, err := usecase.ConfirmTOTP(ctx, user.ID, "123"); !errors.Is(err, types.ErrInvalidMFACode) {
+		t.Errorf("ConfirmTOTP() wrong code error = %v, want %v", err, types.ErrInvalidMFACode)
+	}
+
+	codes, err := usecase.ConfirmTOTP(ctx, user.ID, totpCode(totpStep(time.Now())))
+	if err != nil {
+		t.Fatalf("ConfirmTOTP() unexpected error = %v", err)
+	}
+
+	if len(codes) != 3 {
+		t.Errorf("ConfirmTOTP() returned %d recovery codes, want 3", len(codes))
+	}
+
+	stored, _ = usecase.GetByID(ctx, user.ID)
+	if !stored.IsMFAEnabled() {
+		t.Error("MFA not enabled after confirmation")
+	}
+
+	if len(stored.RecoveryCodeHashes) != len(codes) {
+		t.Errorf("stored %d recovery code hashes, want %d", len(stored.RecoveryCodeHashes), len(codes))
+	}
+
+	if _, err := usecase.EnrollTOTP(ctx, user.ID); !errors.Is(err, types.ErrMFAAlreadyEnabled) {
+		t.Errorf("EnrollTOTP() when enabled error = %v, want %v", err, types.ErrMFAAlreadyEnabled)
+	}
+}
+
+func TestUserUsecase_VerifyMFA(t *testing.T) {
+	usecase := newMFAUsecase()
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if _, err := usecase.EnrollTOTP(ctx, user.ID); err != nil {
+		t.Fatalf("EnrollTOTP() unexpected error = %v", err)
+	}
+
+	step := totpStep(time.Now())
+
+	codes, err := usecase.ConfirmTOTP(ctx, user.ID, totpCode(step))
+	if err != nil {
+		t.Fatalf("ConfirmTOTP() unexpected error = %v", err)
+	}
+
+	login := func() string {
+		t.Helper()
+
+		result, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"})
+		if err != nil {
+			t.Fatalf("Authenticate() unexpected error = %v", err)
+		}
+
+		if result.MFAChallenge == nil || result.Session != nil || result.AccessToken.Token != "" {
+			t.Fatalf("Authenticate() with MFA = %+v, want only challenge", result)
+		}
+
+		return result.MFAChallenge.Token
+	}
+
+	token := login()
+
+	// Код, уже принятый при подтверждении, повторно не принимается.
+	if _, err := usecase.VerifyMFA(ctx, models.VerifyMFAInput{Token: token, Code: totpCode(step)}); !errors.Is(err, types.ErrInvalidMFACode) {
+		t.Errorf("VerifyMFA() replayed code error = %v, want %v", err, types.ErrInvalidMFACode)
+	}
+
+	result, err := usecase.VerifyMFA(ctx, models.VerifyMFAInput{Token: token, Code: totpCode(step + 1)})
+	if err != nil {
+		t.Fatalf("VerifyMFA() unexpected error = %v", err)
+	}
+
+	if result.Session == nil || result.AccessToken.Token == "" {
+		t.Errorf("VerifyMFA() = %+v, want session and tokens", result)
+	}
+
+	if _, err := usecase.VerifyMFA(ctx, models.VerifyMFAInput{Token: token, Code: totpCode(step + 1)}); !errors.Is(err, types.ErrInvalidToken) {
+		t.Errorf("VerifyMFA() reused token error = %v, want %v", err, types.ErrInvalidToken)
+	}
+
+	// Код восстановления принимается без учёта регистра и только один раз.
+	recovery := strings.ToUpper(codes[0])
+	if _, err := usecase.VerifyMFA(ctx, models.VerifyMFAInput{Token: login(), Code: recovery}); err != nil {
+		t.Errorf("VerifyMFA() recovery code unexpected error = %v", err)
+	}
+
+	if _, err := usecase.VerifyMFA(ctx, models.VerifyMFAInput{Token: login(), Code: recovery}); !errors.Is(err, types.ErrInvalidMFACode) {
+		t.Errorf("VerifyMFA() used recovery code error = %v, want %v", err, types.ErrInvalidMFACode)
+	}
+
+	// После MaxAttempts неверных кодов токен перестаёт действовать.
+	token = login()
+	for i := 0; i < 3; i++ {
+		if _, err := usecase.VerifyMFA(ctx, models.VerifyMFAInput{Token: token, Code: "000000"}); !errors.Is(err, types.ErrInvalidMFACode) {
+			t.Fatalf("VerifyMFA() wrong code error = %v, want %v", err, types.ErrInvalidMFACode)
+		}
+	}
+
+	if _, err := usecase.VerifyMFA(ctx, models.VerifyMFAInput{Token: token, Code: codes[1]}); !errors.Is(err, types.ErrInvalidToken) {
+		t.Errorf("VerifyMFA() after max attempts error = %v, want %v", err, types.ErrInvalidToken)
+	}
+
+	if err := usecase.DisableTOTP(ctx, user.ID, "000000"); !errors.Is(err, types.ErrInvalidMFACode) {
+		t.Errorf("DisableTOTP() wrong code error = %v, want %v", err, types.ErrInvalidMFACode)
+	}
+
+	if err := usecase.DisableTOTP(ctx, user.ID, codes[1]); err != nil {
+		t.Fatalf("DisableTOTP() unexpected error = %v", err)
+	}
+
+	result, err = usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"})
+	if err != nil || result.MFAChallenge != nil || result.Session == nil {
+		t.Errorf("Authenticate() after disable = %+v, %v, want session", result, err)
+	}
+}
+
+func TestUserUsecase_DisableTOTPThrottle(t *testing.T) {
+	usecase := NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithMFA(&mockTOTP{}, &mockCipher{}, newMockMFAChallengeRepository(), MFASettings{
+			ChallengeTTL:  time.Minute,
+			MaxAttempts:   3,
+			RecoveryCodes: 3,
+		}),
+		WithLoginThrottle(newMockLoginAttemptRepository(), LoginThrottleSettings{
+			Window:       24 * time.Hour,
+			BaseDelay:    time.Minute,
+			MaxDelay:     10 * time.Minute,
+			LockDuration: time.Hour,
+			User:         LoginThrottleLimits{FreeAttempts: 2, LockAfter: 4},
+		}),
+	)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if _, err := usecase.EnrollTOTP(ctx, user.ID); err != nil {
+		t.Fatalf("EnrollTOTP() unexpected error = %v", err)
+	}
+
+	codes, err := usecase.ConfirmTOTP(ctx, user.ID, totpCode(totpStep(time.Now())))
+	if err != nil {
+		t.Fatalf("ConfirmTOTP() unexpected error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := usecase.DisableTOTP(ctx, user.ID, "000000"); !errors.Is(err, types.ErrInvalidMFACode) {
+			t.Fatalf("DisableTOTP() wrong code error = %v, want %v", err, types.ErrInvalidMFACode)
+		}
+	}
+
+	// После бесплатных попыток подбор замедляется, даже верный код ждёт.
+	var throttled *types.LoginThrottledError
+	if err := usecase.DisableTOTP(ctx, user.ID, codes[0]); !errors.As(err, &throttled) {
+		t.Fatalf("DisableTOTP() after wrong codes error = %v, want throttled", err)
+	}
+
+	if stored, _ := usecase.GetByID(ctx, user.ID); !stored.IsMFAEnabled() {
+		t.Error("MFA disabled while throttled")
+	}
+}
+
+func TestUserUsecase_DisableTOTPInternalError(t *testing.T) {
+	attempts := newMockLoginAttemptRepository()
+	usecase := NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithMFA(&mockTOTP{}, &mockCipher{}, newMockMFAChallengeRepository(), MFASettings{
+			ChallengeTTL:  time.Minute,
+			MaxAttempts:   3,
+			RecoveryCodes: 3,
+		}),
+		WithLoginThrottle(attempts, LoginThrottleSettings{
+			Window:       24 * time.Hour,
+			BaseDelay:    time.Minute,
+			MaxDelay:     10 * time.Minute,
+			LockDuration: time.Hour,
+			User:         LoginThrottleLimits{FreeAttempts: 2, LockAfter: 4},
+		}),
+	)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if _, err := usecase.EnrollTOTP(ctx, user.ID); err != nil {
+		t.Fatalf("EnrollTOTP() unexpected error = %v", err)
+	}
+
+	if _, err := usecase.ConfirmTOTP(ctx, user.ID, totpCode(totpStep(time.Now()))); err != nil {
+		t.Fatalf("ConfirmTOTP() unexpected error = %v", err)
+	}
+
+	usecase.cipher = &failingCipher{}
+
+	for i := 0; i < 5; i++ {
+		if err := usecase.DisableTOTP(ctx, user.ID, totpCode(totpStep(time.Now()))); err == nil || errors.Is(err, types.ErrTooManyAttempts) {
+			t.Fatalf("DisableTOTP() with failing cipher error = %v, want internal error", err)
+		}
+	}
+
+	for key, f := range attempts.failures {
+		if f.Count != 0 {
+			t.Errorf("counter %s = %d after internal errors, want 0", key, f.Count)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// defaultMinPasswordLength - минимальная длина пароля политики по умолчанию.
+const defaultMinPasswordLength = 8
+
+// Option - необязательная настройка UserUsecase.
+type Option func(m *UserUsecase)
+
+// WithMetrics подключает бизнес-метрики пользователей.
+func WithMetrics(metrics UserMetrics) Option {
+	return func(m *UserUsecase) {
+		m.metrics = metrics
+	}
+}
+
+// WithEmailNormalizer подключает канонизацию email. По умолчанию email
+// только приводится к нижнему регистру без пробелов по краям.
+func WithEmailNormalizer(normalizer EmailNormalizer) Option {
+	return func(m *UserUsecase) {
+		m.emails = normalizer
+	}
+}
+
+// WithPasswordPolicy подключает политику паролей. По умолчанию
+// проверяется только минимальная длина.
+func WithPasswordPolicy(policy PasswordPolicy) Option {
+	return func(m *UserUsecase) {
+		m.passwords = policy
+	}
+}
+
+// WithNotifier подключает доставку уведомлений пользователям.
+// По умолчанию уведомления никуда не отправляются.
+func WithNotifier(notifier Notifier) Option {
+	return func(m *UserUsecase) {
+		m.notifier = notifier
+	}
+}
+
+// WithPasswordReset включает сброс пароля по одноразовым токенам.
+// Без этой настройки RequestPasswordReset и ConfirmPasswordReset возвращают ошибку.
+func WithPasswordReset(resets PasswordResetRepository, settings PasswordResetSettings) Option {
+	return func(m *UserUsecase) {
+		m.resets = resets
+		m.resetSettings = settings
+	}
+}
+
+// WithEmailVerification включает подтверждение email одноразовыми токенами:
+// токен выпускается при создании пользователя, при смене email и по запросу
+// ResendEmailVerification.
+func WithEmailVerification(verifications EmailVerificationRepository, settings EmailVerificationSettings) Option {
+	return func(m *UserUsecase) {
+		m.verifications = verifications
+		m.verificationSettings = settings
+	}
+}
+
+// WithMFA включает двухфакторную аутентификацию по TOTP. Секреты
+// шифруются cipher, токены второго шага входа хранятся в challenges.
+func WithMFA(totp TOTPGenerator, cipher SecretCipher, challenges MFAChallengeRepository, settings MFASettings) Option {
+	return func(m *UserUsecase) {
+		m.totp = totp
+		m.cipher = cipher
+		m.challenges = challenges
+		m.mfaSettings = settings
+	}
+}
+
+// WithLoginThrottle включает защиту от перебора паролей: задержки
+// и временную блокировку входа после неудачных попыток.
+func WithLoginThrottle(attempts LoginAttemptRepository, settings LoginThrottleSettings) Option {
+	return func(m *UserUsecase) {
+		m.attempts = attempts
+		m.throttleSettings = settings
+	}
+}
+
+// WithRoles включает хранение ролей пользователей. Новым пользователям
+// выдаётся роль self_service.
+func WithRoles(roles RoleRepository) Option {
+	return func(m *UserUsecase) {
+		m.roles = roles
+	}
+}
+
+// noopMetrics - метрики по умолчанию, ничего не считают.
+type noopMetrics struct{}
+
+func (noopMetrics) IncUsersCreated()          {}
+func (noopMetrics) AddUsersDeleted(_ int)     {}
+func (noopMetrics) IncUsersBlocked()          {}
+func (noopMetrics) IncLoginFailures()         {}
+func (noopMetrics) IncLoginLockouts(_ string) {}
+
+// noopNotifier - уведомления по умолчанию, ничего не отправляют.
+type noopNotifier struct{}
+
+func (noopNotifier) SendPasswordReset(_ context.Context, _ *models.User, _ string, _ time.Time) error {
+	return nil
+}
+
+func (noopNotifier) SendEmailVerification(_ context.Context, _ *models.User, _, _ string, _ time.Time) error {
+	return nil
+}
+
+// basicEmailNormalizer - канонизация email по умолчанию.
+type basicEmailNormalizer struct{}
+
+func (basicEmailNormalizer) Normalize(email string) (string, error) {
+	return strings.ToLower(strings.TrimSpace(email)), nil
+}
+
+// minLengthPolicy - политика паролей по умолчанию.
+type minLengthPolicy struct{}
+
+func (minLengthPolicy) Validate(password string, _ ...string) error {
+	if utf8.RuneCountInString(password) >= defaultMinPasswordLength {
+		return nil
+	}
+
+	return &types.PasswordPolicyError{Violations: []types.PasswordViolation{{
+		Reason:  types.PasswordTooShort,
+		Message: fmt.Sprintf("must be at least %d characters long", defaultMinPasswordLength),
+	}}}
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// sortFields - поля, по которым клиент может сортировать пользователей.
+var sortFields = map[string]models.SortField{
+	string(models.SortByCreatedAt): models.SortByCreatedAt,
+	string(models.SortByUpdatedAt): models.SortByUpdatedAt,
+	string(models.SortByEmail):     models.SortByEmail,
+	string(models.SortByName):      models.SortByName,
+}
+
+// parseOrderBy разбирает сортировку в формате "created_at desc, email".
+// Направление по умолчанию - asc. Пустая строка - сортировка по умолчанию.
+func parseOrderBy(orderBy string) ([]models.SortOrder, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+
+	var orders []models.SortOrder
+
+	seen := make(map[models.SortField]bool)
+
+	for _, part := range strings.Split(orderBy, ",") {
+		tokens := strings.Fields(part)
+		if len(tokens) == 0 || len(tokens) > 2 {
+			return nil, fmt.Errorf("%w: %q", types.ErrInvalidOrderBy, part)
+		}
+
+		field, ok := sortFields[strings.ToLower(tokens[0])]
+		if !ok || seen[field] {
+			return nil, fmt.Errorf("%w: %q", types.ErrInvalidOrderBy, tokens[0])
+		}
+
+		seen[field] = true
+		order := models.SortOrder{Field: field}
+
+		if len(tokens) == 2 {
+			switch strings.ToLower(tokens[1]) {
+			case "asc":
+			case "desc":
+				order.Desc = true
+			default:
+				return nil, fmt.Errorf("%w: %q", types.ErrInvalidOrderBy, tokens[1])
+			}
+		}
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+// formatOrderBy возвращает каноническое представление сортировки.
+func formatOrderBy(orders []models.SortOrder) string {
+	parts := make([]string, len(orders))
+
+	for i, order := range orders {
+		parts[i] = string(order.Field)
+		if order.Desc {
+			parts[i] += " desc"
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,279 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// maxOrganizationNameLength - максимальная длина названия организации в символах.
+const maxOrganizationNameLength = 255
+
+// OrganizationRepository - интерфейс репозитория организаций.
+// Все методы ограничены арендатором из контекста.
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *models.Organization) error
+	// Find возвращает организации, упорядоченные по названию.
+	// Из pagination учитываются только Limit и Offset.
+	Find(ctx context.Context, filter models.OrganizationFilter, pagination *models.Pagination) ([]*models.Organization, error)
+	Count(ctx context.Context, filter models.OrganizationFilter) (int, error)
+	Delete(ctx context.Context, filter models.OrganizationFilter) (int, error)
+}
+
+// MembershipRepository - интерфейс хранилища членства в организациях.
+type MembershipRepository interface {
+	// Add добавляет участника. Если пользователь уже состоит
+	// в организации, возвращает types.ErrMemberAlreadyExists.
+	Add(ctx context.Context, membership *models.Membership) error
+	Find(ctx context.Context, filter models.MembershipFilter) ([]*models.Membership, error)
+	Remove(ctx context.Context, filter models.MembershipFilter) (int, error)
+}
+
+// UserGetter - интерфейс получения пользователя по ID.
+type UserGetter interface {
+	GetByID(ctx context.Context, id string) (*models.User, error)
+}
+
+// OrganizationUsecase - модуль бизнес-логики организаций.
+type OrganizationUsecase struct {
+	repo    OrganizationRepository
+	members MembershipRepository
+	users   UserGetter
+	idGen   IDGenerator
+}
+
+// NewOrganizationUsecase создаёт новый модуль организаций.
+func NewOrganizationUsecase(
+	repo OrganizationRepository,
+	members MembershipRepository,
+	users UserGetter,
+	idGen IDGenerator,
+) *OrganizationUsecase {
+	return &OrganizationUsecase{
+		repo:    repo,
+		members: members,
+		users:   users,
+		idGen:   idGen,
+	}
+}
+
+// Create создаёт организацию в арендаторе из контекста.
+func (m *OrganizationUsecase) Create(ctx context.Context, input models.CreateOrganizationInput) (*models.Organization, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxOrganizationNameLength {
+		return nil, types.ErrInvalidOrganizationName
+	}
+
+	now := time.Now()
+	org := &models.Organization{
+		ID:        m.idGen.Generate(),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := m.repo.Create(ctx, org); err != nil {
+		return nil, fmt.Errorf("create organization: %w", err)
+	}
+
+	slog.InfoContext(ctx, "organization created", slog.String("organization_id", org.ID))
+
+	return org, nil
+}
+
+// GetByID возвращает организацию по ID.
+func (m *OrganizationUsecase) GetByID(ctx context.Context, id string) (*models.Organization, error) {
+	orgs, err := m.repo.Find(ctx, models.OrganizationFilter{IDs: []string{id}}, &models.Pagination{Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("get organization: %w", err)
+	}
+
+	if len(orgs) == 0 {
+		return nil, types.ErrOrganizationNotFound
+	}
+
+	return orgs[0], nil
+}
+
+// OrganizationListFilter - параметры метода List.
+type OrganizationListFilter struct {
+	Limit  int
+	Offset int
+}
+
+// OrganizationListResult - результат метода List.
+type OrganizationListResult struct {
+	Organizations []*models.Organization
+	Total         int
+}
+
+// List возвращает страницу организаций, упорядоченных по названию.
+func (m *OrganizationUsecase) List(ctx context.Context, filter OrganizationListFilter) (*OrganizationListResult, error) {
+	pagination := &models.Pagination{
+		Limit:  normalizeLimit(filter.Limit),
+		Offset: normalizeOffset(filter.Offset),
+	}
+
+	orgs, err := m.repo.Find(ctx, models.OrganizationFilter{}, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("list organizations: %w", err)
+	}
+
+	total, err := m.repo.Count(ctx, models.OrganizationFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("count organizations: %w", err)
+	}
+
+	return &OrganizationListResult{
+		Organizations: orgs,
+		Total:         total,
+	}, nil
+}
+
+// Delete удаляет организацию вместе с членством в ней.
+func (m *OrganizationUsecase) Delete(ctx context.Context, id string) error {
+	if _, err := m.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	if _, err := m.members.Remove(ctx, models.MembershipFilter{OrganizationIDs: []string{id}}); err != nil {
+		return fmt.Errorf("remove members: %w", err)
+	}
+
+	if _, err := m.repo.Delete(ctx, models.OrganizationFilter{IDs: []string{id}}); err != nil {
+		return fmt.Errorf("delete organization: %w", err)
+	}
+
+	slog.InfoContext(ctx, "organization deleted", slog.String("organization_id", id))
+
+	return nil
+}
+
+// AddMember добавляет пользователя в организацию. Без роли
+// пользователь добавляется рядовым участником.
+func (m *OrganizationUsecase) AddMember(ctx context.Context, input models.AddMemberInput) (*models.Membership, error) {
+	role := input.Role
+	if role == "" {
+		role = types.MemberRoleMember
+	}
+
+	if !role.IsValid() {
+		return nil, types.ErrInvalidMemberRole
+	}
+
+	if _, err := m.GetByID(ctx, input.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	// Пользователь ищется в том же арендаторе, что и организация.
+	if _, err := m.users.GetByID(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+
+	membership := &models.Membership{
+		OrganizationID: input.OrganizationID,
+		UserID:         input.UserID,
+		Role:           role,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := m.members.Add(ctx, membership); err != nil {
+		return nil, fmt.Errorf("add member: %w", err)
+	}
+
+	slog.InfoContext(ctx, "organization member added",
+		slog.String("organization_id", membership.OrganizationID),
+		slog.String("user_id", membership.UserID),
+		slog.String("role", string(membership.Role)),
+	)
+
+	return membership, nil
+}
+
+// RemoveMember исключает пользователя из организации.
+func (m *OrganizationUsecase) RemoveMember(ctx context.Context, orgID, userID string) error {
+	if _, err := m.GetByID(ctx, orgID); err != nil {
+		return err
+	}
+
+	count, err := m.members.Remove(ctx, models.MembershipFilter{
+		OrganizationIDs: []string{orgID},
+		UserIDs:         []string{userID},
+	})
+	if err != nil {
+		return fmt.Errorf("remove member: %w", err)
+	}
+
+	if count == 0 {
+		return types.ErrMemberNotFound
+	}
+
+	slog.InfoContext(ctx, "organization member removed",
+		slog.String("organization_id", orgID),
+		slog.String("user_id", userID),
+	)
+
+	return nil
+}
+
+// ListMembers возвращает участников организации в порядке добавления.
+func (m *OrganizationUsecase) ListMembers(ctx context.Context, orgID string) ([]*models.Membership, error) {
+	if _, err := m.GetByID(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	members, err := m.members.Find(ctx, models.MembershipFilter{OrganizationIDs: []string{orgID}})
+	if err != nil {
+		return nil, fmt.Errorf("list members: %w", err)
+	}
+
+	return members, nil
+}
+
+// ListUserOrganizations возвращает организации пользователя
+// с его ролью в каждой, упорядоченные по названию.
+func (m *OrganizationUsecase) ListUserOrganizations(ctx context.Context, userID string) ([]*models.UserOrganization, error) {
+	if _, err := m.users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	memberships, err := m.members.Find(ctx, models.MembershipFilter{UserIDs: []string{userID}})
+	if err != nil {
+		return nil, fmt.Errorf("list memberships: %w", err)
+	}
+
+	if len(memberships) == 0 {
+		return nil, nil
+	}
+
+	byOrg := make(map[string]*models.Membership, len(memberships))
+	orgIDs := make([]string, len(memberships))
+
+	for i, membership := range memberships {
+		byOrg[membership.OrganizationID] = membership
+		orgIDs[i] = membership.OrganizationID
+	}
+
+	orgs, err := m.repo.Find(ctx, models.OrganizationFilter{IDs: orgIDs}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("find organizations: %w", err)
+	}
+
+	result := make([]*models.UserOrganization, len(orgs))
+	for i, org := range orgs {
+		membership := byOrg[org.ID]
+		result[i] = &models.UserOrganization{
+			Organization: org,
+			Role:         membership.Role,
+			JoinedAt:     membership.CreatedAt,
+		}
+	}
+
+	return result, nil
+}
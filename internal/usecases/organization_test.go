@@ -0,0 +1,221 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+type mockOrganizationRepository struct {
+	orgs map[string]*models.Organization
+}
+
+func newMockOrganizationRepository() *mockOrganizationRepository {
+	return &mockOrganizationRepository{orgs: make(map[string]*models.Organization)}
+}
+
+func (m *mockOrganizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	stored := *org
+	m.orgs[org.ID] = &stored
+	return nil
+}
+
+func (m *mockOrganizationRepository) Find(ctx context.Context, filter models.OrganizationFilter, pagination *models.Pagination) ([]*models.Organization, error) {
+	var result []*models.Organization
+	for _, org := range m.orgs {
+		if len(filter.IDs) == 0 || slices.Contains(filter.IDs, org.ID) {
+			found := *org
+			result = append(result, &found)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	if pagination != nil {
+		result = result[min(pagination.Offset, len(result)):]
+		if pagination.Limit > 0 && pagination.Limit < len(result) {
+			result = result[:pagination.Limit]
+		}
+	}
+	return result, nil
+}
+
+func (m *mockOrganizationRepository) Count(ctx context.Context, filter models.OrganizationFilter) (int, error) {
+	orgs, err := m.Find(ctx, filter, nil)
+	return len(orgs), err
+}
+
+func (m *mockOrganizationRepository) Delete(ctx context.Context, filter models.OrganizationFilter) (int, error) {
+	count := 0
+	for id := range m.orgs {
+		if slices.Contains(filter.IDs, id) {
+			delete(m.orgs, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+type mockMembershipRepository struct {
+	memberships []*models.Membership
+}
+
+func (m *mockMembershipRepository) Add(ctx context.Context, membership *models.Membership) error {
+	for _, existing := range m.memberships {
+		if existing.OrganizationID == membership.OrganizationID && existing.UserID == membership.UserID {
+			return types.ErrMemberAlreadyExists
+		}
+	}
+	stored := *membership
+	m.memberships = append(m.memberships, &stored)
+	return nil
+}
+
+func (m *mockMembershipRepository) Find(ctx context.Context, filter models.MembershipFilter) ([]*models.Membership, error) {
+	var result []*models.Membership
+	for _, membership := range m.memberships {
+		if m.matchesFilter(membership, filter) {
+			found := *membership
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMembershipRepository) Remove(ctx context.Context, filter models.MembershipFilter) (int, error) {
+	before := len(m.memberships)
+	m.memberships = slices.DeleteFunc(m.memberships, func(membership *models.Membership) bool {
+		return m.matchesFilter(membership, filter)
+	})
+	return before - len(m.memberships), nil
+}
+
+func (m *mockMembershipRepository) matchesFilter(membership *models.Membership, filter models.MembershipFilter) bool {
+	if len(filter.OrganizationIDs) > 0 && !slices.Contains(filter.OrganizationIDs, membership.OrganizationID) {
+		return false
+	}
+	if len(filter.UserIDs) > 0 && !slices.Contains(filter.UserIDs, membership.UserID) {
+		return false
+	}
+	return true
+}
+
+func newOrganizationUsecases() (*UserUsecase, *OrganizationUsecase, *mockMembershipRepository) {
+	memberships := &mockMembershipRepository{}
+	users := NewUserUsecase(newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{})
+	orgs := NewOrganizationUsecase(newMockOrganizationRepository(), memberships, users, &mockIDGen{})
+
+	return users, orgs, memberships
+}
+
+func TestOrganizationUsecase_Members(t *testing.T) {
+	users, orgs, _ := newOrganizationUsecases()
+	ctx := context.Background()
+
+	user, err := users.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if _, err := orgs.Create(ctx, models.CreateOrganizationInput{Name: "   "}); !errors.Is(err, types.ErrInvalidOrganizationName) {
+		t.Errorf("Create() blank name error = %v, want %v", err, types.ErrInvalidOrganizationName)
+	}
+
+	org, err := orgs.Create(ctx, models.CreateOrganizationInput{Name: " Acme "})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if org.Name != "Acme" {
+		t.Errorf("Create() name = %q, want %q", org.Name, "Acme")
+	}
+
+	// Без роли пользователь добавляется рядовым участником.
+	member, err := orgs.AddMember(ctx, models.AddMemberInput{OrganizationID: org.ID, UserID: user.ID})
+	if err != nil {
+		t.Fatalf("AddMember() unexpected error = %v", err)
+	}
+
+	if member.Role != types.MemberRoleMember {
+		t.Errorf("AddMember() role = %q, want %q", member.Role, types.MemberRoleMember)
+	}
+
+	tests := []struct {
+		name    string
+		input   models.AddMemberInput
+		wantErr error
+	}{
+		{"duplicate", models.AddMemberInput{OrganizationID: org.ID, UserID: user.ID}, types.ErrMemberAlreadyExists},
+		{"invalid role", models.AddMemberInput{OrganizationID: org.ID, UserID: user.ID, Role: "root"}, types.ErrInvalidMemberRole},
+		{"unknown organization", models.AddMemberInput{OrganizationID: "missing", UserID: user.ID}, types.ErrOrganizationNotFound},
+		{"unknown user", models.AddMemberInput{OrganizationID: org.ID, UserID: "missing"}, types.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := orgs.AddMember(ctx, tt.input); !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddMember() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	userOrgs, err := orgs.ListUserOrganizations(ctx, user.ID)
+	if err != nil || len(userOrgs) != 1 || userOrgs[0].Organization.ID != org.ID || userOrgs[0].Role != types.MemberRoleMember {
+		t.Errorf("ListUserOrganizations() = %+v, %v, want %s as member", userOrgs, err, org.ID)
+	}
+
+	if err := orgs.RemoveMember(ctx, org.ID, user.ID); err != nil {
+		t.Fatalf("RemoveMember() unexpected error = %v", err)
+	}
+
+	if err := orgs.RemoveMember(ctx, org.ID, user.ID); !errors.Is(err, types.ErrMemberNotFound) {
+		t.Errorf("RemoveMember() twice error = %v, want %v", err, types.ErrMemberNotFound)
+	}
+
+	if members, _ := orgs.ListMembers(ctx, org.ID); len(members) != 0 {
+		t.Errorf("ListMembers() after remove = %d members, want 0", len(members))
+	}
+}
+
+func TestOrganizationUsecase_DeleteCascade(t *testing.T) {
+	users, orgs, memberships := newOrganizationUsecases()
+	ctx := context.Background()
+
+	alice, _ := users.Create(ctx, models.CreateUserInput{Email: "alice@example.com", Password: "password123"})
+	bob, _ := users.Create(ctx, models.CreateUserInput{Email: "bob@example.com", Password: "password123"})
+
+	acme, _ := orgs.Create(ctx, models.CreateOrganizationInput{Name: "Acme"})
+	globex, _ := orgs.Create(ctx, models.CreateOrganizationInput{Name: "Globex"})
+
+	for _, input := range []models.AddMemberInput{
+		{OrganizationID: acme.ID, UserID: alice.ID, Role: types.MemberRoleOwner},
+		{OrganizationID: acme.ID, UserID: bob.ID},
+		{OrganizationID: globex.ID, UserID: alice.ID},
+	} {
+		if _, err := orgs.AddMember(ctx, input); err != nil {
+			t.Fatalf("AddMember() unexpected error = %v", err)
+		}
+	}
+
+	// Удаление организации удаляет членство в ней.
+	if err := orgs.Delete(ctx, acme.ID); err != nil {
+		t.Fatalf("Delete() organization unexpected error = %v", err)
+	}
+
+	if _, err := orgs.GetByID(ctx, acme.ID); !errors.Is(err, types.ErrOrganizationNotFound) {
+		t.Errorf("GetByID() after delete error = %v, want %v", err, types.ErrOrganizationNotFound)
+	}
+
+	remaining, _ := memberships.Find(ctx, models.MembershipFilter{})
+	if len(remaining) != 1 || remaining[0].OrganizationID != globex.ID {
+		t.Errorf("memberships after organization delete = %+v, want only %s", remaining, globex.ID)
+	}
+
+	result, err := orgs.List(ctx, OrganizationListFilter{})
+	if err != nil || result.Total != 1 || result.Organizations[0].ID != globex.ID {
+		t.Errorf("List() = %+v, %v, want only %s", result, err, globex.ID)
+	}
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// pageToken - содержимое непрозрачного токена страницы.
+// OrderBy фиксирует сортировку: токен нельзя использовать с другой.
+type pageToken struct {
+	OrderBy   string `json:"o,omitempty"`
+	CreatedAt int64  `json:"c"`
+	UpdatedAt int64  `json:"u"`
+	Email     string `json:"e,omitempty"`
+	Name      string `json:"n,omitempty"`
+	ID        string `json:"i"`
+}
+
+// encodePageToken кодирует курсор в токен для клиента.
+func encodePageToken(cursor *models.Cursor, orders []models.SortOrder) string {
+	data, _ := json.Marshal(pageToken{
+		OrderBy:   formatOrderBy(orders),
+		CreatedAt: cursor.CreatedAt.UnixNano(),
+		UpdatedAt: cursor.UpdatedAt.UnixNano(),
+		Email:     cursor.Email,
+		Name:      cursor.Name,
+		ID:        cursor.ID,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken восстанавливает курсор из токена клиента.
+func decodePageToken(token string, orders []models.SortOrder) (*models.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, types.ErrInvalidPageToken
+	}
+
+	var pt pageToken
+	if err := json.Unmarshal(data, &pt); err != nil || pt.ID == "" {
+		return nil, types.ErrInvalidPageToken
+	}
+
+	if pt.OrderBy != formatOrderBy(orders) {
+		return nil, types.ErrInvalidPageToken
+	}
+
+	return &models.Cursor{
+		CreatedAt: time.Unix(0, pt.CreatedAt),
+		UpdatedAt: time.Unix(0, pt.UpdatedAt),
+		Email:     pt.Email,
+		Name:      pt.Name,
+		ID:        pt.ID,
+	}, nil
+}
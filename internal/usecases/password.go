@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// ChangePassword меняет пароль пользователя после проверки текущего.
+// Неверные пароли учитываются в счётчике пользователя защиты от перебора,
+// иначе с украденным access токеном пароль можно было бы подбирать без ограничений.
+func (m *UserUsecase) ChangePassword(ctx context.Context, id string, input models.ChangePasswordInput) (*models.User, error) {
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{id}})
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if user.IsBlocked() {
+		return nil, types.ErrUserBlocked
+	}
+
+	attempt, err := m.reserveLoginAttempt(ctx, user.EmailNormalized, models.ClientInfo{})
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.hasher.Compare(user.PasswordHash, input.OldPassword) {
+		slog.InfoContext(ctx, "password change rejected", slog.String("user_id", user.ID))
+		m.failLoginAttempt(ctx, attempt)
+		return nil, types.ErrWrongPassword
+	}
+
+	if err := m.succeedLoginAttempt(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	if err := m.setPassword(ctx, user, input.NewPassword, input.RevokeSessions); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SetPassword устанавливает пароль пользователя без проверки текущего.
+// Предназначен для администраторов и сервисных сценариев.
+func (m *UserUsecase) SetPassword(ctx context.Context, id string, input models.SetPasswordInput) (*models.User, error) {
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{id}})
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if err := m.setPassword(ctx, user, input.Password, input.RevokeSessions); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// setPassword проверяет пароль политикой, сохраняет новый хэш и
+// при необходимости отзывает сессии пользователя.
+func (m *UserUsecase) setPassword(ctx context.Context, user *models.User, password string, revokeSessions bool) error {
+	if err := m.passwords.Validate(password, user.Email, user.Name); err != nil {
+		return err
+	}
+
+	hash, err := m.hasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+
+	now := time.Now()
+	user.PasswordHash = hash
+	user.PasswordChangedAt = now
+	user.UpdatedAt = now
+
+	if err := m.repo.Update(ctx, user); err != nil {
+		return fmt.Errorf("update password: %w", err)
+	}
+
+	slog.InfoContext(ctx, "password changed", slog.String("user_id", user.ID))
+
+	if revokeSessions {
+		if _, err := m.revokeAllSessions(ctx, user.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
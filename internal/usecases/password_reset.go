@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// errPasswordResetDisabled - сброс пароля не настроен через WithPasswordReset.
+var errPasswordResetDisabled = errors.New("password reset is not configured")
+
+// PasswordResetRepository - интерфейс репозитория токенов сброса пароля.
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *models.PasswordReset) error
+	Find(ctx context.Context, filter models.PasswordResetFilter) ([]*models.PasswordReset, error)
+	Count(ctx context.Context, filter models.PasswordResetFilter) (int, error)
+	// Use помечает неиспользованные токены по фильтру использованными.
+	// Возвращает количество помеченных.
+	Use(ctx context.Context, filter models.PasswordResetFilter, usedAt time.Time) (int, error)
+}
+
+// PasswordResetSettings - настройки сброса пароля.
+type PasswordResetSettings struct {
+	TokenTTL time.Duration
+	// MaxRequests - сколько токенов можно выпустить одному пользователю за Window.
+	// Запросы сверх лимита молча игнорируются.
+	MaxRequests int
+	Window      time.Duration
+}
+
+// RequestPasswordReset выпускает токен сброса пароля и отправляет его пользователю.
+// Ответ не зависит от того, существует ли email, чтобы не раскрывать наличие аккаунта.
+func (m *UserUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	if m.resets == nil {
+		return errPasswordResetDisabled
+	}
+
+	normalized, err := m.normalizeEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	user, err := m.findOne(ctx, models.UserFilter{Emails: []string{normalized}})
+	if err != nil {
+		if types.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if user.IsBlocked() {
+		slog.InfoContext(ctx, "password reset skipped for blocked user", slog.String("user_id", user.ID))
+		return nil
+	}
+
+	now := time.Now()
+
+	if m.resetSettings.MaxRequests > 0 {
+		windowStart := now.Add(-m.resetSettings.Window)
+
+		count, err := m.resets.Count(ctx, models.PasswordResetFilter{
+			UserIDs:      []string{user.ID},
+			CreatedAfter: &windowStart,
+		})
+		if err != nil {
+			return fmt.Errorf("count password resets: %w", err)
+		}
+
+		if count >= m.resetSettings.MaxRequests {
+			slog.InfoContext(ctx, "password reset rate limited", slog.String("user_id", user.ID))
+			return nil
+		}
+	}
+
+	token, hash, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+
+	reset := &models.PasswordReset{
+		ID:        m.idGen.Generate(),
+		UserID:    user.ID,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(m.resetSettings.TokenTTL),
+	}
+
+	if err := m.resets.Create(ctx, reset); err != nil {
+		return fmt.Errorf("create password reset: %w", err)
+	}
+
+	if err := m.notifier.SendPasswordReset(ctx, user, token, reset.ExpiresAt); err != nil {
+		return fmt.Errorf("send password reset: %w", err)
+	}
+
+	slog.InfoContext(ctx, "password reset requested", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+// ConfirmPasswordReset устанавливает новый пароль по токену сброса.
+// Токен одноразовый: после успешной смены он и все остальные токены
+// пользователя перестают действовать, а сессии пользователя отзываются.
+func (m *UserUsecase) ConfirmPasswordReset(ctx context.Context, input models.ConfirmPasswordResetInput) error {
+	if m.resets == nil {
+		return errPasswordResetDisabled
+	}
+
+	now := time.Now()
+
+	resets, err := m.resets.Find(ctx, models.PasswordResetFilter{
+		TokenHashes: []string{hashSecretToken(input.Token)},
+		ActiveAt:    &now,
+	})
+	if err != nil {
+		return fmt.Errorf("find password reset: %w", err)
+	}
+
+	if len(resets) == 0 {
+		return types.ErrInvalidToken
+	}
+
+	reset := resets[0]
+
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{reset.UserID}})
+	if err != nil {
+		if types.IsNotFound(err) {
+			return types.ErrInvalidToken
+		}
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if user.IsBlocked() {
+		return types.ErrUserBlocked
+	}
+
+	// Политику проверяем до использования токена, чтобы пользователь мог
+	// повторить попытку с другим паролем.
+	if err := m.passwords.Validate(input.NewPassword, user.Email, user.Name); err != nil {
+		return err
+	}
+
+	// Токен помечается использованным условно, поэтому из двух
+	// параллельных запросов с одним токеном пройдёт только один.
+	used, err := m.resets.Use(ctx, models.PasswordResetFilter{IDs: []string{reset.ID}, ActiveAt: &now}, now)
+	if err != nil {
+		return fmt.Errorf("use password reset: %w", err)
+	}
+
+	if used == 0 {
+		return types.ErrInvalidToken
+	}
+
+	if _, err := m.resets.Use(ctx, models.PasswordResetFilter{UserIDs: []string{user.ID}, ActiveAt: &now}, now); err != nil {
+		return fmt.Errorf("use password resets: %w", err)
+	}
+
+	return m.setPassword(ctx, user, input.NewPassword, true)
+}
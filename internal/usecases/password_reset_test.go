@@ -0,0 +1,159 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+type mockPasswordResetRepository struct {
+	resets map[string]*models.PasswordReset
+}
+
+func newMockPasswordResetRepository() *mockPasswordResetRepository {
+	return &mockPasswordResetRepository{resets: make(map[string]*models.PasswordReset)}
+}
+
+func (m *mockPasswordResetRepository) Create(ctx context.Context, reset *models.PasswordReset) error {
+	stored := *reset
+	m.resets[reset.ID] = &stored
+	return nil
+}
+
+func (m *mockPasswordResetRepository) Find(ctx context.Context, filter models.PasswordResetFilter) ([]*models.PasswordReset, error) {
+	var result []*models.PasswordReset
+	for _, reset := range m.resets {
+		if m.matchesFilter(reset, filter) {
+			found := *reset
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPasswordResetRepository) Count(ctx context.Context, filter models.PasswordResetFilter) (int, error) {
+	resets, _ := m.Find(ctx, filter)
+	return len(resets), nil
+}
+
+func (m *mockPasswordResetRepository) Use(ctx context.Context, filter models.PasswordResetFilter, usedAt time.Time) (int, error) {
+	count := 0
+	for _, reset := range m.resets {
+		if reset.UsedAt == nil && m.matchesFilter(reset, filter) {
+			reset.UsedAt = &usedAt
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockPasswordResetRepository) matchesFilter(reset *models.PasswordReset, filter models.PasswordResetFilter) bool {
+	if len(filter.IDs) > 0 && !containsString(filter.IDs, reset.ID) {
+		return false
+	}
+	if len(filter.UserIDs) > 0 && !containsString(filter.UserIDs, reset.UserID) {
+		return false
+	}
+	if len(filter.TokenHashes) > 0 && !containsString(filter.TokenHashes, reset.TokenHash) {
+		return false
+	}
+	if filter.CreatedAfter != nil && !reset.CreatedAt.After(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.ActiveAt != nil && !reset.IsActive(*filter.ActiveAt) {
+		return false
+	}
+	return true
+}
+
+// mockNotifier запоминает отправленные токены по email.
+type mockNotifier struct {
+	resetTokens        map[string][]string
+	verificationTokens map[string][]string
+}
+
+func newMockNotifier() *mockNotifier {
+	return &mockNotifier{
+		resetTokens:        make(map[string][]string),
+		verificationTokens: make(map[string][]string),
+	}
+}
+
+func (m *mockNotifier) SendPasswordReset(ctx context.Context, user *models.User, token string, expiresAt time.Time) error {
+	m.resetTokens[user.Email] = append(m.resetTokens[user.Email], token)
+	return nil
+}
+
+func (m *mockNotifier) SendEmailVerification(ctx context.Context, user *models.User, email, token string, expiresAt time.Time) error {
+	m.verificationTokens[email] = append(m.verificationTokens[email], token)
+	return nil
+}
+
+func TestUserUsecase_PasswordReset(t *testing.T) {
+	notifier := newMockNotifier()
+	usecase := NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithNotifier(notifier),
+		WithPasswordReset(newMockPasswordResetRepository(), PasswordResetSettings{
+			TokenTTL:    time.Hour,
+			MaxRequests: 2,
+			Window:      time.Hour,
+		}),
+	)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	login, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"})
+	if err != nil {
+		t.Fatalf("Authenticate() unexpected error = %v", err)
+	}
+
+	// Неизвестный email не отличается от известного.
+	if err := usecase.RequestPasswordReset(ctx, "unknown@example.com"); err != nil {
+		t.Errorf("RequestPasswordReset() unknown email error = %v, want nil", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := usecase.RequestPasswordReset(ctx, "USER@example.com"); err != nil {
+			t.Fatalf("RequestPasswordReset() unexpected error = %v", err)
+		}
+	}
+
+	tokens := notifier.resetTokens[user.Email]
+	if len(tokens) != 2 {
+		t.Fatalf("RequestPasswordReset() sent %d tokens, want 2 (rate limited)", len(tokens))
+	}
+
+	err = usecase.ConfirmPasswordReset(ctx, models.ConfirmPasswordResetInput{Token: tokens[0], NewPassword: "short"})
+	if !errors.Is(err, types.ErrInvalidPassword) {
+		t.Errorf("ConfirmPasswordReset() short password error = %v, want %v", err, types.ErrInvalidPassword)
+	}
+
+	if err := usecase.ConfirmPasswordReset(ctx, models.ConfirmPasswordResetInput{Token: tokens[0], NewPassword: "new-password"}); err != nil {
+		t.Fatalf("ConfirmPasswordReset() unexpected error = %v", err)
+	}
+
+	// Токен одноразовый, второй токен пользователя тоже перестаёт действовать.
+	for _, token := range tokens {
+		err := usecase.ConfirmPasswordReset(ctx, models.ConfirmPasswordResetInput{Token: token, NewPassword: "other-password"})
+		if !errors.Is(err, types.ErrInvalidToken) {
+			t.Errorf("ConfirmPasswordReset() reused token error = %v, want %v", err, types.ErrInvalidToken)
+		}
+	}
+
+	if _, err := usecase.Refresh(ctx, login.RefreshToken.Token, models.ClientInfo{}); !errors.Is(err, types.ErrInvalidToken) {
+		t.Errorf("Refresh() after password reset error = %v, want %v", err, types.ErrInvalidToken)
+	}
+
+	if _, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "new-password"}); err != nil {
+		t.Errorf("Authenticate() with new password unexpected error = %v", err)
+	}
+}
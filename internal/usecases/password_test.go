@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+func TestUserUsecase_ChangePassword(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	login, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"})
+	if err != nil {
+		t.Fatalf("Authenticate() unexpected error = %v", err)
+	}
+
+	_, err = usecase.ChangePassword(ctx, user.ID, models.ChangePasswordInput{OldPassword: "wrong-password", NewPassword: "new-password"})
+	if !errors.Is(err, types.ErrWrongPassword) {
+		t.Errorf("ChangePassword() wrong old password error = %v, want %v", err, types.ErrWrongPassword)
+	}
+
+	_, err = usecase.ChangePassword(ctx, user.ID, models.ChangePasswordInput{OldPassword: "password123", NewPassword: "short"})
+	if !errors.Is(err, types.ErrInvalidPassword) {
+		t.Errorf("ChangePassword() short password error = %v, want %v", err, types.ErrInvalidPassword)
+	}
+
+	changed, err := usecase.ChangePassword(ctx, user.ID, models.ChangePasswordInput{
+		OldPassword:    "password123",
+		NewPassword:    "new-password",
+		RevokeSessions: true,
+	})
+	if err != nil {
+		t.Fatalf("ChangePassword() unexpected error = %v", err)
+	}
+
+	if changed.PasswordChangedAt.Before(user.CreatedAt) {
+		t.Errorf("ChangePassword() password_changed_at = %v, want >= %v", changed.PasswordChangedAt, user.CreatedAt)
+	}
+
+	if _, err := usecase.Refresh(ctx, login.RefreshToken.Token, models.ClientInfo{}); !errors.Is(err, types.ErrInvalidToken) {
+		t.Errorf("Refresh() after password change error = %v, want %v", err, types.ErrInvalidToken)
+	}
+
+	if _, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"}); !errors.Is(err, types.ErrInvalidCredentials) {
+		t.Errorf("Authenticate() with old password error = %v, want %v", err, types.ErrInvalidCredentials)
+	}
+
+	if _, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "new-password"}); err != nil {
+		t.Errorf("Authenticate() with new password unexpected error = %v", err)
+	}
+}
+
+func TestUserUsecase_ChangePasswordThrottle(t *testing.T) {
+	attempts := newMockLoginAttemptRepository()
+	usecase := newThrottleUsecase(attempts)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	wrong := models.ChangePasswordInput{OldPassword: "wrong-password", NewPassword: "new-password"}
+
+	// Ждать задержку между попытками не нужно: время ошибок сдвигается назад.
+	for i := 0; i < 4; i++ {
+		if _, err := usecase.ChangePassword(ctx, user.ID, wrong); !errors.Is(err, types.ErrWrongPassword) {
+			t.Fatalf("ChangePassword() attempt %d error = %v, want %v", i+1, err, types.ErrWrongPassword)
+		}
+		attempts.rewind(10 * time.Minute)
+	}
+
+	// После LockAfter ошибок даже верный пароль отклоняется до конца блокировки.
+	var throttled *types.LoginThrottledError
+	_, err = usecase.ChangePassword(ctx, user.ID, models.ChangePasswordInput{OldPassword: "password123", NewPassword: "new-password"})
+	if !errors.As(err, &throttled) || !throttled.Locked {
+		t.Fatalf("ChangePassword() after wrong passwords error = %v, want lockout", err)
+	}
+
+	if _, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"}); !errors.As(err, &throttled) {
+		t.Errorf("Authenticate() after wrong passwords error = %v, want throttled", err)
+	}
+}
+
+func TestUserUsecase_SetPassword(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	login, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"})
+	if err != nil {
+		t.Fatalf("Authenticate() unexpected error = %v", err)
+	}
+
+	if _, err := usecase.SetPassword(ctx, user.ID, models.SetPasswordInput{Password: "admin-chosen"}); err != nil {
+		t.Fatalf("SetPassword() unexpected error = %v", err)
+	}
+
+	// Без RevokeSessions старые сессии продолжают работать.
+	if _, err := usecase.Refresh(ctx, login.RefreshToken.Token, models.ClientInfo{}); err != nil {
+		t.Errorf("Refresh() after SetPassword unexpected error = %v", err)
+	}
+
+	if _, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "admin-chosen"}); err != nil {
+		t.Errorf("Authenticate() with new password unexpected error = %v", err)
+	}
+
+	if _, err := usecase.SetPassword(ctx, "unknown", models.SetPasswordInput{Password: "admin-chosen"}); !errors.Is(err, types.ErrUserNotFound) {
+		t.Errorf("SetPassword() unknown user error = %v, want %v", err, types.ErrUserNotFound)
+	}
+}
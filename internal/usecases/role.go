@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// errRolesDisabled - роли не настроены через WithRoles.
+var errRolesDisabled = errors.New("roles are not configured")
+
+// RoleRepository - интерфейс хранилища ролей пользователей.
+type RoleRepository interface {
+	List(ctx context.Context, userID string) ([]types.Role, error)
+	// Add выдаёт роль. Повторная выдача той же роли не считается ошибкой.
+	Add(ctx context.Context, userID string, role types.Role) error
+	Remove(ctx context.Context, userID string, role types.Role) error
+}
+
+// UserRoles возвращает роли пользователя, которому разрешено работать
+// с сервисом. Для удалённого пользователя возвращает types.ErrUserNotFound,
+// для заблокированного и неактивного - ошибки checkCanLogin: роли
+// таких пользователей сохраняются, но пользоваться ими нельзя.
+func (m *UserUsecase) UserRoles(ctx context.Context, userID string) ([]types.Role, error) {
+	if m.roles == nil {
+		return nil, errRolesDisabled
+	}
+
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{userID}})
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if err := checkCanLogin(user); err != nil {
+		return nil, err
+	}
+
+	return m.listRoles(ctx, userID)
+}
+
+// listRoles возвращает роли пользователя без проверки его состояния.
+func (m *UserUsecase) listRoles(ctx context.Context, userID string) ([]types.Role, error) {
+	roles, err := m.roles.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// AssignRole выдаёт пользователю роль и возвращает его роли.
+func (m *UserUsecase) AssignRole(ctx context.Context, userID string, role types.Role) ([]types.Role, error) {
+	if err := m.checkRoleChange(ctx, userID, role); err != nil {
+		return nil, err
+	}
+
+	if err := m.roles.Add(ctx, userID, role); err != nil {
+		return nil, fmt.Errorf("add role: %w", err)
+	}
+
+	slog.InfoContext(ctx, "role assigned", slog.String("user_id", userID), slog.String("role", string(role)))
+
+	return m.listRoles(ctx, userID)
+}
+
+// RevokeRole отзывает роль пользователя и возвращает оставшиеся роли.
+func (m *UserUsecase) RevokeRole(ctx context.Context, userID string, role types.Role) ([]types.Role, error) {
+	if err := m.checkRoleChange(ctx, userID, role); err != nil {
+		return nil, err
+	}
+
+	if err := m.roles.Remove(ctx, userID, role); err != nil {
+		return nil, fmt.Errorf("remove role: %w", err)
+	}
+
+	slog.InfoContext(ctx, "role revoked", slog.String("user_id", userID), slog.String("role", string(role)))
+
+	return m.listRoles(ctx, userID)
+}
+
+// checkRoleChange проверяет, что роли настроены, роль известна
+// и пользователь существует.
+func (m *UserUsecase) checkRoleChange(ctx context.Context, userID string, role types.Role) error {
+	if m.roles == nil {
+		return errRolesDisabled
+	}
+
+	if !role.IsValid() {
+		return types.ErrInvalidRole
+	}
+
+	if _, err := m.findOne(ctx, models.UserFilter{IDs: []string{userID}}); err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	return nil
+}
+
+// assignDefaultRoles выдаёт новому пользователю роль self_service.
+func (m *UserUsecase) assignDefaultRoles(ctx context.Context, user *models.User) error {
+	if m.roles == nil {
+		return nil
+	}
+
+	if err := m.roles.Add(ctx, user.ID, types.RoleSelfService); err != nil {
+		return fmt.Errorf("add default role: %w", err)
+	}
+
+	return nil
+}
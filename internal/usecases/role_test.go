@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	"github.com/obsessed-gopher/micro-service-guide/internal/utils"
+)
+
+type mockRoleRepository struct {
+	roles map[string][]types.Role
+}
+
+func newMockRoleRepository() *mockRoleRepository {
+	return &mockRoleRepository{roles: make(map[string][]types.Role)}
+}
+
+func (m *mockRoleRepository) List(ctx context.Context, userID string) ([]types.Role, error) {
+	return slices.Clone(m.roles[userID]), nil
+}
+
+func (m *mockRoleRepository) Add(ctx context.Context, userID string, role types.Role) error {
+	if !slices.Contains(m.roles[userID], role) {
+		m.roles[userID] = append(m.roles[userID], role)
+	}
+	return nil
+}
+
+func (m *mockRoleRepository) Remove(ctx context.Context, userID string, role types.Role) error {
+	m.roles[userID] = slices.DeleteFunc(m.roles[userID], func(r types.Role) bool { return r == role })
+	return nil
+}
+
+func TestUserUsecase_Roles(t *testing.T) {
+	usecase := NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithRoles(newMockRoleRepository()),
+	)
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "admin@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	roles, err := usecase.UserRoles(ctx, user.ID)
+	if err != nil || !slices.Equal(roles, []types.Role{types.RoleSelfService}) {
+		t.Errorf("UserRoles() after create = %v, %v, want [self_service]", roles, err)
+	}
+
+	roles, err = usecase.AssignRole(ctx, user.ID, types.RoleAdmin)
+	if err != nil || !slices.Contains(roles, types.RoleAdmin) {
+		t.Errorf("AssignRole() = %v, %v, want admin", roles, err)
+	}
+
+	roles, err = usecase.RevokeRole(ctx, user.ID, types.RoleSelfService)
+	if err != nil || !slices.Equal(roles, []types.Role{types.RoleAdmin}) {
+		t.Errorf("RevokeRole() = %v, %v, want [admin]", roles, err)
+	}
+
+	if _, err := usecase.AssignRole(ctx, user.ID, "root"); !errors.Is(err, types.ErrInvalidRole) {
+		t.Errorf("AssignRole() unknown role error = %v, want %v", err, types.ErrInvalidRole)
+	}
+
+	if _, err := usecase.AssignRole(ctx, "missing", types.RoleAdmin); !errors.Is(err, types.ErrUserNotFound) {
+		t.Errorf("AssignRole() missing user error = %v, want %v", err, types.ErrUserNotFound)
+	}
+}
+
+func TestUserUsecase_UserRolesCallerState(t *testing.T) {
+	usecase := NewUserUsecase(
+		newMockRepository(), newMockSessionRepository(), &mockHasher{}, &mockIDGen{}, &mockTokenManager{},
+		WithRoles(newMockRoleRepository()),
+	)
+	ctx := context.Background()
+
+	create := func(email string) *models.User {
+		user, err := usecase.Create(ctx, models.CreateUserInput{Email: email, Password: "password123"})
+		if err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+		return user
+	}
+
+	blocked := create("blocked@example.com")
+	if _, err := usecase.Update(ctx, blocked.ID, models.UpdateUserInput{Status: utils.Ptr(types.UserStatusBlocked)}); err != nil {
+		t.Fatalf("Update() block unexpected error = %v", err)
+	}
+
+	inactive := create("inactive@example.com")
+	if _, err := usecase.Update(ctx, inactive.ID, models.UpdateUserInput{Status: utils.Ptr(types.UserStatusInactive)}); err != nil {
+		t.Fatalf("Update() deactivate unexpected error = %v", err)
+	}
+
+	deleted := create("deleted@example.com")
+	if _, err := usecase.Delete(ctx, models.UserFilter{IDs: []string{deleted.ID}}); err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr error
+	}{
+		{"blocked", blocked.ID, types.ErrUserBlocked},
+		{"inactive", inactive.ID, types.ErrUserInactive},
+		{"deleted", deleted.ID, types.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := usecase.UserRoles(ctx, tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("UserRoles() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Администратор по-прежнему управляет ролями заблокированного пользователя.
+	if _, err := usecase.AssignRole(ctx, blocked.ID, types.RoleSupport); err != nil {
+		t.Errorf("AssignRole() blocked user unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// secretTokenSize - размер случайной части одноразовых токенов в байтах.
+const secretTokenSize = 32
+
+// newSecretToken генерирует случайный одноразовый токен и его хэш для хранения.
+// Используется для сброса пароля и подтверждения email.
+func newSecretToken() (string, string, error) {
+	raw := make([]byte, secretTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("generate secret token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	return token, hashSecretToken(token), nil
+}
+
+// hashSecretToken возвращает хэш одноразового токена для хранения и поиска.
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+)
+
+// Refresh обменивает refresh токен на новую пару токенов.
+// Refresh токен ротируется: старый после обмена больше не действителен.
+func (m *UserUsecase) Refresh(ctx context.Context, refreshToken string, client models.ClientInfo) (*models.AuthResult, error) {
+	now := time.Now()
+
+	sessions, err := m.sessions.Find(ctx, models.SessionFilter{
+		RefreshTokenHashes: []string{m.tokens.HashRefreshToken(refreshToken)},
+		ActiveAt:           &now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find session: %w", err)
+	}
+
+	if len(sessions) == 0 {
+		return nil, types.ErrInvalidToken
+	}
+
+	session := sessions[0]
+	previousHash := session.RefreshTokenHash
+
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{session.UserID}})
+	if err != nil {
+		if types.IsNotFound(err) {
+			return nil, types.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if err := checkCanLogin(user); err != nil {
+		return nil, err
+	}
+
+	refresh, err := m.tokens.IssueRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("issue refresh token: %w", err)
+	}
+
+	session.RefreshTokenHash = refresh.Hash
+	session.ExpiresAt = refresh.ExpiresAt
+	session.LastUsedAt = now
+	session.UserAgent = client.UserAgent
+	session.IP = client.IP
+
+	if err := m.sessions.Rotate(ctx, session, previousHash); err != nil {
+		if errors.Is(err, types.ErrInvalidToken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("rotate session: %w", err)
+	}
+
+	return m.issueTokens(user, session, refresh)
+}
+
+// RevokeSession отзывает одну активную сессию.
+// Сессии пользователей другого арендатора не находятся.
+func (m *UserUsecase) RevokeSession(ctx context.Context, sessionID string) error {
+	now := time.Now()
+
+	sessions, err := m.sessions.Find(ctx, models.SessionFilter{
+		IDs:      []string{sessionID},
+		ActiveAt: &now,
+	})
+	if err != nil {
+		return fmt.Errorf("find session: %w", err)
+	}
+
+	if len(sessions) == 0 {
+		return types.ErrSessionNotFound
+	}
+
+	if _, err := m.findOne(ctx, models.UserFilter{IDs: []string{sessions[0].UserID}}); err != nil {
+		if types.IsNotFound(err) {
+			return types.ErrSessionNotFound
+		}
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	count, err := m.sessions.Revoke(ctx, models.SessionFilter{
+		IDs:      []string{sessionID},
+		ActiveAt: &now,
+	}, now)
+	if err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+
+	if count == 0 {
+		return types.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllSessions отзывает все активные сессии пользователя.
+// Возвращает количество отозванных.
+func (m *UserUsecase) RevokeAllSessions(ctx context.Context, userID string) (int, error) {
+	if _, err := m.findOne(ctx, models.UserFilter{IDs: []string{userID}}); err != nil {
+		return 0, err
+	}
+
+	return m.revokeAllSessions(ctx, userID)
+}
+
+// revokeAllSessions отзывает сессии уже найденного пользователя.
+func (m *UserUsecase) revokeAllSessions(ctx context.Context, userID string) (int, error) {
+	now := time.Now()
+
+	count, err := m.sessions.Revoke(ctx, models.SessionFilter{
+		UserIDs:  []string{userID},
+		ActiveAt: &now,
+	}, now)
+	if err != nil {
+		return 0, fmt.Errorf("revoke sessions: %w", err)
+	}
+
+	slog.InfoContext(ctx, "sessions revoked", slog.String("user_id", userID), slog.Int("count", count))
+
+	return count, nil
+}
+
+// ListSessions возвращает активные сессии пользователя.
+func (m *UserUsecase) ListSessions(ctx context.Context, userID string) ([]*models.Session, error) {
+	if _, err := m.findOne(ctx, models.UserFilter{IDs: []string{userID}}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	sessions, err := m.sessions.Find(ctx, models.SessionFilter{
+		UserIDs:  []string{userID},
+		ActiveAt: &now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
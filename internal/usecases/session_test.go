@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/obsessed-gopher/micro-service-guide/internal/models"
+	"github.com/obsessed-gopher/micro-service-guide/internal/types"
+	"github.com/obsessed-gopher/micro-service-guide/internal/utils"
+)
+
+func TestUserUsecase_Refresh(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	if _, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"}); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	login, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Authenticate() unexpected error = %v", err)
+	}
+
+	refreshed, err := usecase.Refresh(ctx, login.RefreshToken.Token, models.ClientInfo{IP: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("Refresh() unexpected error = %v", err)
+	}
+
+	if refreshed.Session.ID != login.Session.ID {
+		t.Errorf("Refresh() session = %v, want %v", refreshed.Session.ID, login.Session.ID)
+	}
+
+	if refreshed.RefreshToken.Token == login.RefreshToken.Token {
+		t.Error("Refresh() did not rotate refresh token")
+	}
+
+	if _, err := usecase.Refresh(ctx, login.RefreshToken.Token, models.ClientInfo{}); !errors.Is(err, types.ErrInvalidToken) {
+		t.Errorf("Refresh() with rotated token error = %v, want %v", err, types.ErrInvalidToken)
+	}
+}
+
+func TestUserUsecase_RefreshConcurrent(t *testing.T) {
+	usecase, _, sessions := newTestUsecase()
+	ctx := context.Background()
+
+	if _, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"}); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	login, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Authenticate() unexpected error = %v", err)
+	}
+
+	// Второй обмен того же токена успевает завершиться, пока первый
+	// уже нашёл сессию, но ещё не сохранил её.
+	var raceErr error
+	sessions.beforeRotate = func() {
+		_, raceErr = usecase.Refresh(ctx, login.RefreshToken.Token, models.ClientInfo{})
+	}
+
+	_, err = usecase.Refresh(ctx, login.RefreshToken.Token, models.ClientInfo{})
+
+	if raceErr != nil {
+		t.Fatalf("Refresh() first to rotate unexpected error = %v", raceErr)
+	}
+
+	if !errors.Is(err, types.ErrInvalidToken) {
+		t.Errorf("Refresh() same token concurrently error = %v, want %v", err, types.ErrInvalidToken)
+	}
+}
+
+func TestUserUsecase_RevokeSessions(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	input := models.AuthenticateInput{Email: user.Email, Password: "password123"}
+
+	first, err := usecase.Authenticate(ctx, input)
+	if err != nil {
+		t.Fatalf("Authenticate() unexpected error = %v", err)
+	}
+
+	if _, err := usecase.Authenticate(ctx, input); err != nil {
+		t.Fatalf("Authenticate() unexpected error = %v", err)
+	}
+
+	if err := usecase.RevokeSession(ctx, first.Session.ID); err != nil {
+		t.Fatalf("RevokeSession() unexpected error = %v", err)
+	}
+
+	if err := usecase.RevokeSession(ctx, first.Session.ID); !errors.Is(err, types.ErrSessionNotFound) {
+		t.Errorf("RevokeSession() twice error = %v, want %v", err, types.ErrSessionNotFound)
+	}
+
+	sessions, err := usecase.ListSessions(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("ListSessions() unexpected error = %v", err)
+	}
+
+	if len(sessions) != 1 {
+		t.Fatalf("ListSessions() len = %d, want 1", len(sessions))
+	}
+
+	// Блокировка пользователя отзывает оставшиеся сессии.
+	blocked := types.UserStatusBlocked
+	if _, err := usecase.Update(ctx, user.ID, models.UpdateUserInput{Status: &blocked}); err != nil {
+		t.Fatalf("Update() unexpected error = %v", err)
+	}
+
+	sessions, err = usecase.ListSessions(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("ListSessions() unexpected error = %v", err)
+	}
+
+	if len(sessions) != 0 {
+		t.Errorf("ListSessions() after block len = %d, want 0", len(sessions))
+	}
+
+	if _, err := usecase.Update(ctx, user.ID, models.UpdateUserInput{Name: utils.Ptr("name")}); !errors.Is(err, types.ErrUserBlocked) {
+		t.Errorf("Update() of blocked user error = %v, want %v", err, types.ErrUserBlocked)
+	}
+}
@@ -4,7 +4,9 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/obsessed-gopher/micro-service-guide/internal/models"
@@ -16,14 +18,44 @@ type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	Find(ctx context.Context, filter models.UserFilter, pagination *models.Pagination) ([]*models.User, error)
 	Count(ctx context.Context, filter models.UserFilter) (int, error)
+	Search(ctx context.Context, query string, filter models.UserFilter, pagination *models.Pagination) ([]*models.User, int, error)
 	Update(ctx context.Context, user *models.User) error
-	Delete(ctx context.Context, filter models.UserFilter) (int, error)
+	// Delete мягко удаляет пользователей и в той же операции исключает
+	// их из всех организаций.
+	Delete(ctx context.Context, filter models.UserFilter, deletedAt time.Time) (int, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+// SessionRepository - интерфейс репозитория сессий.
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	Find(ctx context.Context, filter models.SessionFilter) ([]*models.Session, error)
+	// Rotate сохраняет сессию с новым refresh токеном, только если она
+	// не отозвана и её токен всё ещё previousHash. Иначе возвращает
+	// types.ErrInvalidToken: токен уже обменян параллельным запросом.
+	Rotate(ctx context.Context, session *models.Session, previousHash string) error
+	Revoke(ctx context.Context, filter models.SessionFilter, revokedAt time.Time) (int, error)
 }
 
 // PasswordHasher - интерфейс для хэширования паролей.
+// NeedsRehash сообщает, что хэш создан устаревшим алгоритмом или
+// параметрами и его нужно пересчитать при следующем входе.
 type PasswordHasher interface {
 	Hash(password string) (string, error)
 	Compare(hash, password string) bool
+	NeedsRehash(hash string) bool
+}
+
+// EmailNormalizer - интерфейс канонизации email для проверки уникальности.
+type EmailNormalizer interface {
+	Normalize(email string) (string, error)
+}
+
+// PasswordPolicy - интерфейс проверки пароля на соответствие политике.
+// personal - данные пользователя (email, имя), которые пароль не должен содержать.
+// Нарушения возвращаются как *types.PasswordPolicyError.
+type PasswordPolicy interface {
+	Validate(password string, personal ...string) error
 }
 
 // IDGenerator - интерфейс генератора ID.
@@ -31,23 +63,137 @@ type IDGenerator interface {
 	Generate() string
 }
 
+// TokenManager - интерфейс выпуска токенов доступа.
+type TokenManager interface {
+	IssueAccessToken(claims models.TokenClaims) (models.AccessToken, error)
+	IssueRefreshToken() (models.RefreshToken, error)
+	HashRefreshToken(token string) string
+}
+
+// Notifier - интерфейс доставки уведомлений пользователям.
+// SendEmailVerification отправляет токен на подтверждаемый адрес email,
+// который при смене email отличается от текущего адреса пользователя.
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, user *models.User, token string, expiresAt time.Time) error
+	SendEmailVerification(ctx context.Context, user *models.User, email, token string, expiresAt time.Time) error
+}
+
+// UserMetrics - интерфейс бизнес-метрик пользователей.
+type UserMetrics interface {
+	IncUsersCreated()
+	AddUsersDeleted(n int)
+	IncUsersBlocked()
+	IncLoginFailures()
+	// IncLoginLockouts учитывает блокировку входа по пользователю ("user") или IP ("ip").
+	IncLoginLockouts(scope string)
+}
+
 // UserUsecase - модуль бизнес-логики пользователей.
 type UserUsecase struct {
-	repo   UserRepository
-	hasher PasswordHasher
-	idGen  IDGenerator
+	repo      UserRepository
+	sessions  SessionRepository
+	hasher    PasswordHasher
+	idGen     IDGenerator
+	tokens    TokenManager
+	emails    EmailNormalizer
+	passwords PasswordPolicy
+	notifier  Notifier
+	metrics   UserMetrics
+
+	resets        PasswordResetRepository
+	resetSettings PasswordResetSettings
+
+	verifications        EmailVerificationRepository
+	verificationSettings EmailVerificationSettings
+
+	totp        TOTPGenerator
+	cipher      SecretCipher
+	challenges  MFAChallengeRepository
+	mfaSettings MFASettings
+
+	attempts         LoginAttemptRepository
+	throttleSettings LoginThrottleSettings
+
+	roles RoleRepository
+
+	dummyHash func() string
 }
 
 // NewUserUsecase создаёт новый модуль пользователей.
-func NewUserUsecase(repo UserRepository, hasher PasswordHasher, idGen IDGenerator) *UserUsecase {
-	return &UserUsecase{
-		repo:   repo,
-		hasher: hasher,
-		idGen:  idGen,
+func NewUserUsecase(
+	repo UserRepository,
+	sessions SessionRepository,
+	hasher PasswordHasher,
+	idGen IDGenerator,
+	tokens TokenManager,
+	opts ...Option,
+) *UserUsecase {
+	m := &UserUsecase{
+		repo:      repo,
+		sessions:  sessions,
+		hasher:    hasher,
+		idGen:     idGen,
+		tokens:    tokens,
+		emails:    basicEmailNormalizer{},
+		passwords: minLengthPolicy{},
+		notifier:  noopNotifier{},
+		metrics:   noopMetrics{},
+		dummyHash: newDummyHash(hasher),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// emailRegex проверяет каноничную форму email: домены IDN к этому моменту уже в punycode.
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.([a-zA-Z]{2,}|xn--[a-zA-Z0-9-]+)$`)
+
+// normalizeEmail проверяет email и возвращает его каноничную форму.
+func (m *UserUsecase) normalizeEmail(email string) (string, error) {
+	normalized, err := m.emails.Normalize(email)
+	if err != nil || !emailRegex.MatchString(normalized) {
+		return "", types.ErrInvalidEmail
 	}
+
+	return normalized, nil
 }
 
-var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+// normalizeEmails канонизирует email адреса фильтра.
+func (m *UserUsecase) normalizeEmails(emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	result := make([]string, len(emails))
+
+	for i, email := range emails {
+		normalized, err := m.normalizeEmail(email)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = normalized
+	}
+
+	return result, nil
+}
+
+// checkEmailAvailable проверяет, что каноничный email не занят другим пользователем.
+func (m *UserUsecase) checkEmailAvailable(ctx context.Context, normalized, userID string) error {
+	existing, err := m.findOne(ctx, models.UserFilter{Emails: []string{normalized}})
+	if err != nil && !types.IsNotFound(err) {
+		return fmt.Errorf("check existing user: %w", err)
+	}
+
+	if existing != nil && existing.ID != userID {
+		return types.ErrUserAlreadyExists
+	}
+
+	return nil
+}
 
 // findOne возвращает одного пользователя по фильтру или ErrUserNotFound.
 func (m *UserUsecase) findOne(ctx context.Context, filter models.UserFilter) (*models.User, error) {
@@ -65,20 +211,17 @@ func (m *UserUsecase) findOne(ctx context.Context, filter models.UserFilter) (*m
 
 // Create создаёт нового пользователя.
 func (m *UserUsecase) Create(ctx context.Context, input models.CreateUserInput) (*models.User, error) {
-	if !emailRegex.MatchString(input.Email) {
-		return nil, types.ErrInvalidEmail
+	email, err := m.normalizeEmail(input.Email)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(input.Password) < 8 {
-		return nil, types.ErrInvalidPassword
+	if err := m.passwords.Validate(input.Password, input.Email, input.Name); err != nil {
+		return nil, err
 	}
 
-	existing, err := m.findOne(ctx, models.UserFilter{Emails: []string{input.Email}})
-	if err != nil && !types.IsNotFound(err) {
-		return nil, fmt.Errorf("check existing user: %w", err)
-	}
-	if existing != nil {
-		return nil, types.ErrUserAlreadyExists
+	if err := m.checkEmailAvailable(ctx, email, ""); err != nil {
+		return nil, err
 	}
 
 	hash, err := m.hasher.Hash(input.Password)
@@ -86,21 +229,49 @@ func (m *UserUsecase) Create(ctx context.Context, input models.CreateUserInput)
 		return nil, fmt.Errorf("hash password: %w", err)
 	}
 
+	status := types.UserStatusActive
+	if m.verifications != nil && m.verificationSettings.RequireVerification {
+		status = types.UserStatusInactive
+	}
+
 	now := time.Now()
 	user := &models.User{
-		ID:           m.idGen.Generate(),
-		Email:        input.Email,
-		Name:         input.Name,
-		PasswordHash: hash,
-		Status:       types.UserStatusActive,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:                m.idGen.Generate(),
+		Email:             strings.TrimSpace(input.Email),
+		EmailNormalized:   email,
+		Name:              input.Name,
+		PasswordHash:      hash,
+		PasswordChangedAt: now,
+		Status:            status,
+		Version:           1,
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}
 
 	if err := m.repo.Create(ctx, user); err != nil {
 		return nil, fmt.Errorf("create user: %w", err)
 	}
 
+	m.metrics.IncUsersCreated()
+
+	slog.InfoContext(ctx, "user created", slog.String("user_id", user.ID))
+
+	// Без роли пользователь не получит доступа к своей записи, но
+	// создание не откатываем: роль может выдать администратор.
+	if err := m.assignDefaultRoles(ctx, user); err != nil {
+		slog.ErrorContext(ctx, "failed to assign default roles",
+			slog.String("user_id", user.ID), slog.Any("error", err))
+	}
+
+	// Пользователь уже создан, поэтому ошибку отправки не возвращаем:
+	// токен можно выпустить повторно через ResendEmailVerification.
+	if m.verifications != nil {
+		if err := m.sendEmailVerification(ctx, user, user.Email, user.EmailNormalized); err != nil {
+			slog.ErrorContext(ctx, "failed to send email verification",
+				slog.String("user_id", user.ID), slog.Any("error", err))
+		}
+	}
+
 	return user, nil
 }
 
@@ -115,6 +286,10 @@ func (m *UserUsecase) GetByID(ctx context.Context, id string) (*models.User, err
 
 // Update обновляет данные пользователя.
 func (m *UserUsecase) Update(ctx context.Context, id string, input models.UpdateUserInput) (*models.User, error) {
+	if input.Status != nil && !input.Status.IsValid() {
+		return nil, types.ErrInvalidStatus
+	}
+
 	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{id}})
 	if err != nil {
 		return nil, fmt.Errorf("get user: %w", err)
@@ -124,11 +299,32 @@ func (m *UserUsecase) Update(ctx context.Context, id string, input models.Update
 		return nil, types.ErrUserBlocked
 	}
 
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != user.Version {
+		return nil, types.ErrVersionConflict
+	}
+
+	var pendingEmail, pendingNormalized string
+
 	if input.Email != nil {
-		if !emailRegex.MatchString(*input.Email) {
-			return nil, types.ErrInvalidEmail
+		email, err := m.normalizeEmail(*input.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		if email != user.EmailNormalized {
+			if err := m.checkEmailAvailable(ctx, email, user.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		// При включённом подтверждении новый адрес применяется только после VerifyEmail.
+		// Тот же ящик в другом написании (регистр, точки Gmail) меняем сразу.
+		if m.verifications != nil && email != user.EmailNormalized {
+			pendingEmail, pendingNormalized = strings.TrimSpace(*input.Email), email
+		} else {
+			user.Email = strings.TrimSpace(*input.Email)
+			user.EmailNormalized = email
 		}
-		user.Email = *input.Email
 	}
 
 	if input.Name != nil {
@@ -145,52 +341,204 @@ func (m *UserUsecase) Update(ctx context.Context, id string, input models.Update
 		return nil, fmt.Errorf("update user: %w", err)
 	}
 
+	if pendingEmail != "" {
+		if err := m.sendEmailVerification(ctx, user, pendingEmail, pendingNormalized); err != nil {
+			return nil, err
+		}
+	}
+
+	// Заблокированный пользователь не должен продлевать уже выданные сессии.
+	if user.IsBlocked() {
+		m.metrics.IncUsersBlocked()
+
+		slog.InfoContext(ctx, "user blocked", slog.String("user_id", user.ID))
+
+		if _, err := m.revokeAllSessions(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	return user, nil
 }
 
-// Delete удаляет пользователей по фильтру. Возвращает количество удалённых.
+// Delete мягко удаляет пользователей по фильтру. Возвращает количество удалённых.
+// Удалённого пользователя можно восстановить через Restore до очистки PurgeDeleted,
+// но членство в организациях удаляется сразу и после восстановления не возвращается.
 func (m *UserUsecase) Delete(ctx context.Context, filter models.UserFilter) (int, error) {
-	count, err := m.repo.Delete(ctx, filter)
+	count, err := m.repo.Delete(ctx, filter, time.Now())
 	if err != nil {
 		return 0, fmt.Errorf("delete users: %w", err)
 	}
+
+	m.metrics.AddUsersDeleted(count)
+
+	slog.InfoContext(ctx, "users deleted", slog.Int("count", count))
+
 	return count, nil
 }
 
+// Restore восстанавливает мягко удалённого пользователя.
+func (m *UserUsecase) Restore(ctx context.Context, id string) (*models.User, error) {
+	user, err := m.findOne(ctx, models.UserFilter{IDs: []string{id}, IncludeDeleted: true})
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if !user.IsDeleted() {
+		return user, nil
+	}
+
+	// Пока пользователь был удалён, его email мог занять другой.
+	if err := m.checkEmailAvailable(ctx, user.EmailNormalized, user.ID); err != nil {
+		return nil, err
+	}
+
+	// Сессии, выданные до удаления, не должны ожить после восстановления.
+	if _, err := m.revokeAllSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	user.DeletedAt = nil
+	user.UpdatedAt = time.Now()
+
+	if err := m.repo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("restore user: %w", err)
+	}
+
+	slog.InfoContext(ctx, "user restored", slog.String("user_id", user.ID))
+
+	return user, nil
+}
+
+// PurgeDeleted физически удаляет пользователей, удалённых больше retention назад.
+// Возвращает количество удалённых.
+func (m *UserUsecase) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	count, err := m.repo.Purge(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("purge users: %w", err)
+	}
+
+	if count > 0 {
+		slog.InfoContext(ctx, "deleted users purged", slog.Int("count", count))
+	}
+
+	return count, nil
+}
+
+// Размер страницы List и Search.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// normalizeLimit подставляет размер страницы по умолчанию и ограничивает максимальный.
+func normalizeLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+
+	return min(limit, maxPageSize)
+}
+
+// normalizeOffset заменяет отрицательное смещение нулевым.
+func normalizeOffset(offset int) int {
+	return max(offset, 0)
+}
+
 // ListFilter - параметры для метода List (публичный API usecase).
+// PageToken - токен из NextPageToken предыдущего ответа; если задан, Offset игнорируется.
+// OrderBy - сортировка вида "created_at desc, email"; пустая - новые первыми.
 type ListFilter struct {
-	Filters models.UserFilter
-	Limit   int
-	Offset  int
+	Filters   models.UserFilter
+	Limit     int
+	Offset    int
+	PageToken string
+	OrderBy   string
 }
 
-// List возвращает список пользователей.
-func (m *UserUsecase) List(ctx context.Context, filter ListFilter) ([]*models.User, int, error) {
-	if filter.Limit <= 0 {
-		filter.Limit = 20
-	}
+// ListResult - результат метода List.
+// NextPageToken пуст, если страница последняя.
+type ListResult struct {
+	Users         []*models.User
+	Total         int
+	NextPageToken string
+}
+
+// List возвращает страницу пользователей.
+func (m *UserUsecase) List(ctx context.Context, filter ListFilter) (*ListResult, error) {
+	filter.Limit = normalizeLimit(filter.Limit)
+	filter.Offset = normalizeOffset(filter.Offset)
 
-	if filter.Limit > 100 {
-		filter.Limit = 100
+	repoFilter := filter.Filters
+
+	emails, err := m.normalizeEmails(repoFilter.Emails)
+	if err != nil {
+		return nil, err
 	}
 
-	repoFilter := models.UserFilter{
-		IDs:      filter.Filters.IDs,
-		Emails:   filter.Filters.Emails,
-		Statuses: filter.Filters.Statuses,
+	repoFilter.Emails = emails
+
+	orders, err := parseOrderBy(filter.OrderBy)
+	if err != nil {
+		return nil, err
 	}
 
-	pagination := &models.Pagination{Limit: filter.Limit, Offset: filter.Offset}
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница.
+	pagination := &models.Pagination{Limit: filter.Limit + 1, Offset: filter.Offset, Sort: orders}
+
+	if filter.PageToken != "" {
+		cursor, err := decodePageToken(filter.PageToken, orders)
+		if err != nil {
+			return nil, err
+		}
+
+		pagination.After = cursor
+	}
 
 	users, err := m.repo.Find(ctx, repoFilter, pagination)
 	if err != nil {
-		return nil, 0, fmt.Errorf("list users: %w", err)
+		return nil, fmt.Errorf("list users: %w", err)
 	}
 
 	total, err := m.repo.Count(ctx, repoFilter)
 	if err != nil {
-		return nil, 0, fmt.Errorf("count users: %w", err)
+		return nil, fmt.Errorf("count users: %w", err)
+	}
+
+	result := &ListResult{Users: users, Total: total}
+
+	if len(users) > filter.Limit {
+		result.Users = users[:filter.Limit]
+		result.NextPageToken = encodePageToken(models.CursorOf(result.Users[filter.Limit-1]), orders)
+	}
+
+	return result, nil
+}
+
+// SearchFilter - параметры для метода Search.
+// Query - текст поиска; каждое слово ищется по началу слов в имени и email.
+type SearchFilter struct {
+	Query   string
+	Filters models.UserFilter
+	Limit   int
+	Offset  int
+}
+
+// Search ищет пользователей по имени и email, самые релевантные первыми.
+func (m *UserUsecase) Search(ctx context.Context, filter SearchFilter) (*ListResult, error) {
+	if len(models.SearchTerms(filter.Query)) == 0 {
+		return nil, types.ErrEmptySearchQuery
+	}
+
+	filter.Limit = normalizeLimit(filter.Limit)
+	filter.Offset = normalizeOffset(filter.Offset)
+
+	pagination := &models.Pagination{Limit: filter.Limit, Offset: filter.Offset}
+
+	users, total, err := m.repo.Search(ctx, filter.Query, filter.Filters, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("search users: %w", err)
 	}
 
-	return users, total, nil
+	return &ListResult{Users: users, Total: total}, nil
 }
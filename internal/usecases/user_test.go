@@ -2,7 +2,13 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/obsessed-gopher/micro-service-guide/internal/models"
 	"github.com/obsessed-gopher/micro-service-guide/internal/types"
@@ -27,16 +33,58 @@ func (m *mockRepository) Find(ctx context.Context, filter models.UserFilter, pag
 		if !m.matchesFilter(user, filter) {
 			continue
 		}
+		if pagination != nil && pagination.After != nil &&
+			models.CompareCursors(models.CursorOf(user), pagination.After, pagination.Sort) <= 0 {
+			continue
+		}
 		result = append(result, user)
 	}
+	var orders []models.SortOrder
+	if pagination != nil {
+		orders = pagination.Sort
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return models.CompareCursors(models.CursorOf(result[i]), models.CursorOf(result[j]), orders) < 0
+	})
 	if pagination != nil && pagination.Limit > 0 {
 		start := min(pagination.Offset, len(result))
+		if pagination.After != nil {
+			start = 0
+		}
 		end := min(start+pagination.Limit, len(result))
 		result = result[start:end]
 	}
 	return result, nil
 }
 
+func (m *mockRepository) Search(ctx context.Context, query string, filter models.UserFilter, pagination *models.Pagination) ([]*models.User, int, error) {
+	users, err := m.Find(ctx, filter, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	var result []*models.User
+	for _, user := range users {
+		userTerms := models.SearchTerms(user.Name + " " + user.Email)
+		matched := true
+		for _, term := range models.SearchTerms(query) {
+			if !slices.ContainsFunc(userTerms, func(t string) bool { return strings.HasPrefix(t, term) }) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, user)
+		}
+	}
+	total := len(result)
+	if pagination != nil {
+		start := min(pagination.Offset, len(result))
+		end := min(start+pagination.Limit, len(result))
+		result = result[start:end]
+	}
+	return result, total, nil
+}
+
 func (m *mockRepository) Count(ctx context.Context, filter models.UserFilter) (int, error) {
 	count := 0
 	for _, user := range m.users {
@@ -51,12 +99,32 @@ func (m *mockRepository) matchesFilter(user *models.User, filter models.UserFilt
 	if len(filter.IDs) > 0 && !containsString(filter.IDs, user.ID) {
 		return false
 	}
-	if len(filter.Emails) > 0 && !containsString(filter.Emails, user.Email) {
+	if len(filter.Emails) > 0 && !containsString(filter.Emails, user.EmailNormalized) {
 		return false
 	}
 	if len(filter.Statuses) > 0 && !containsStatus(filter.Statuses, user.Status) {
 		return false
 	}
+	if containsStatus(filter.ExcludeStatuses, user.Status) {
+		return false
+	}
+	if len(filter.EmailDomains) > 0 && !slices.ContainsFunc(filter.EmailDomains, func(d string) bool {
+		return strings.EqualFold(d, models.EmailDomain(user.Email))
+	}) {
+		return false
+	}
+	if filter.EmailMatch.IsSet() && !filter.EmailMatch.Matches(user.Email) {
+		return false
+	}
+	if filter.NameMatch.IsSet() && !filter.NameMatch.Matches(user.Name) {
+		return false
+	}
+	if !filter.CreatedAt.Contains(user.CreatedAt) || !filter.UpdatedAt.Contains(user.UpdatedAt) {
+		return false
+	}
+	if !filter.IncludeDeleted && user.IsDeleted() {
+		return false
+	}
 	return true
 }
 
@@ -79,17 +147,34 @@ func containsStatus(slice []types.UserStatus, val types.UserStatus) bool {
 }
 
 func (m *mockRepository) Update(ctx context.Context, user *models.User) error {
-	if _, ok := m.users[user.ID]; !ok {
+	existing, ok := m.users[user.ID]
+	if !ok {
 		return types.ErrUserNotFound
 	}
+	if existing.Version != user.Version {
+		return types.ErrVersionConflict
+	}
+	user.Version++
 	m.users[user.ID] = user
 	return nil
 }
 
-func (m *mockRepository) Delete(ctx context.Context, filter models.UserFilter) (int, error) {
+func (m *mockRepository) Delete(ctx context.Context, filter models.UserFilter, deletedAt time.Time) (int, error) {
+	filter.IncludeDeleted = false
+	count := 0
+	for _, user := range m.users {
+		if m.matchesFilter(user, filter) {
+			user.DeletedAt = &deletedAt
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
 	var toDelete []string
 	for id, user := range m.users {
-		if m.matchesFilter(user, filter) {
+		if user.IsDeleted() && user.DeletedAt.Before(deletedBefore) {
 			toDelete = append(toDelete, id)
 		}
 	}
@@ -109,6 +194,23 @@ func (m *mockHasher) Compare(hash, password string) bool {
 	return hash == "hashed_"+password
 }
 
+func (m *mockHasher) NeedsRehash(hash string) bool {
+	return false
+}
+
+// upgradingHasher принимает и устаревшие хэши "legacy_", требуя их пересчёта.
+type upgradingHasher struct {
+	mockHasher
+}
+
+func (m *upgradingHasher) Compare(hash, password string) bool {
+	return hash == "hashed_"+password || hash == "legacy_"+password
+}
+
+func (m *upgradingHasher) NeedsRehash(hash string) bool {
+	return strings.HasPrefix(hash, "legacy_")
+}
+
 type mockIDGen struct {
 	counter int
 }
@@ -118,11 +220,100 @@ func (m *mockIDGen) Generate() string {
 	return "test-id-" + string(rune('0'+m.counter))
 }
 
-func TestUserUsecase_Create(t *testing.T) {
+type mockSessionRepository struct {
+	sessions map[string]*models.Session
+	// beforeRotate вызывается один раз перед следующим Rotate.
+	beforeRotate func()
+}
+
+func newMockSessionRepository() *mockSessionRepository {
+	return &mockSessionRepository{sessions: make(map[string]*models.Session)}
+}
+
+func (m *mockSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	stored := *session
+	m.sessions[session.ID] = &stored
+	return nil
+}
+
+func (m *mockSessionRepository) Find(ctx context.Context, filter models.SessionFilter) ([]*models.Session, error) {
+	var result []*models.Session
+	for _, session := range m.sessions {
+		if m.matchesFilter(session, filter) {
+			found := *session
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSessionRepository) Rotate(ctx context.Context, session *models.Session, previousHash string) error {
+	if hook := m.beforeRotate; hook != nil {
+		m.beforeRotate = nil
+		hook()
+	}
+	stored, ok := m.sessions[session.ID]
+	if !ok || stored.RevokedAt != nil || stored.RefreshTokenHash != previousHash {
+		return types.ErrInvalidToken
+	}
+	rotated := *session
+	m.sessions[session.ID] = &rotated
+	return nil
+}
+
+func (m *mockSessionRepository) Revoke(ctx context.Context, filter models.SessionFilter, revokedAt time.Time) (int, error) {
+	count := 0
+	for _, session := range m.sessions {
+		if session.RevokedAt == nil && m.matchesFilter(session, filter) {
+			session.RevokedAt = &revokedAt
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockSessionRepository) matchesFilter(session *models.Session, filter models.SessionFilter) bool {
+	if len(filter.IDs) > 0 && !containsString(filter.IDs, session.ID) {
+		return false
+	}
+	if len(filter.UserIDs) > 0 && !containsString(filter.UserIDs, session.UserID) {
+		return false
+	}
+	if len(filter.RefreshTokenHashes) > 0 && !containsString(filter.RefreshTokenHashes, session.RefreshTokenHash) {
+		return false
+	}
+	if filter.ActiveAt != nil && !session.IsActive(*filter.ActiveAt) {
+		return false
+	}
+	return true
+}
+
+type mockTokenManager struct {
+	counter int
+}
+
+func (m *mockTokenManager) IssueAccessToken(claims models.TokenClaims) (models.AccessToken, error) {
+	return models.AccessToken{Token: "token_" + claims.UserID, ExpiresAt: time.Now().Add(time.Minute)}, nil
+}
+
+func (m *mockTokenManager) IssueRefreshToken() (models.RefreshToken, error) {
+	m.counter++
+	token := fmt.Sprintf("refresh_%d", m.counter)
+	return models.RefreshToken{Token: token, Hash: m.HashRefreshToken(token), ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+func (m *mockTokenManager) HashRefreshToken(token string) string {
+	return "hashed_" + token
+}
+
+func newTestUsecase() (*UserUsecase, *mockRepository, *mockSessionRepository) {
 	repo := newMockRepository()
-	hasher := &mockHasher{}
-	idGen := &mockIDGen{}
-	usecase := NewUserUsecase(repo, hasher, idGen)
+	sessions := newMockSessionRepository()
+	return NewUserUsecase(repo, sessions, &mockHasher{}, &mockIDGen{}, &mockTokenManager{}), repo, sessions
+}
+
+func TestUserUsecase_Create(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
 
 	tests := []struct {
 		name    string
@@ -156,6 +347,15 @@ func TestUserUsecase_Create(t *testing.T) {
 			},
 			wantErr: types.ErrInvalidPassword,
 		},
+		{
+			name: "email differs only in case",
+			input: models.CreateUserInput{
+				Email:    " Test@Example.COM",
+				Name:     "Test User",
+				Password: "password123",
+			},
+			wantErr: types.ErrUserAlreadyExists,
+		},
 	}
 
 	for _, tt := range tests {
@@ -163,7 +363,7 @@ func TestUserUsecase_Create(t *testing.T) {
 			user, err := usecase.Create(context.Background(), tt.input)
 
 			if tt.wantErr != nil {
-				if err != tt.wantErr {
+				if !errors.Is(err, tt.wantErr) {
 					t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
 				}
 				return
@@ -184,3 +384,489 @@ func TestUserUsecase_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestUserUsecase_Update_Version(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	if user.Version != 1 {
+		t.Fatalf("Create() version = %d, want 1", user.Version)
+	}
+
+	name := "First"
+	version := user.Version
+
+	updated, err := usecase.Update(ctx, user.ID, models.UpdateUserInput{Name: &name, ExpectedVersion: &version})
+	if err != nil {
+		t.Fatalf("Update() unexpected error = %v", err)
+	}
+
+	if updated.Version != 2 {
+		t.Errorf("Update() version = %d, want 2", updated.Version)
+	}
+
+	// Второй клиент всё ещё видит старую версию.
+	name = "Second"
+
+	_, err = usecase.Update(ctx, user.ID, models.UpdateUserInput{Name: &name, ExpectedVersion: &version})
+	if !errors.Is(err, types.ErrVersionConflict) {
+		t.Errorf("Update() with stale version error = %v, want %v", err, types.ErrVersionConflict)
+	}
+
+	// Без ожидаемой версии обновление проходит безусловно.
+	if _, err := usecase.Update(ctx, user.ID, models.UpdateUserInput{Name: &name}); err != nil {
+		t.Errorf("Update() without version unexpected error = %v", err)
+	}
+}
+
+func TestUserUsecase_Update_Status(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		status  types.UserStatus
+		wantErr error
+	}{
+		{"inactive", types.UserStatusInactive, nil},
+		{"unspecified", types.UserStatusUnspecified, types.ErrInvalidStatus},
+		{"deleted", types.UserStatusDeleted, types.ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := usecase.Update(ctx, user.ID, models.UpdateUserInput{Status: &tt.status})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && updated.Status != tt.status {
+				t.Errorf("Update() status = %v, want %v", updated.Status, tt.status)
+			}
+		})
+	}
+
+	if got, _ := usecase.GetByID(ctx, user.ID); got.Status != types.UserStatusInactive {
+		t.Errorf("GetByID() status = %v, want %v", got.Status, types.UserStatusInactive)
+	}
+}
+
+func TestUserUsecase_DeleteRestore(t *testing.T) {
+	usecase, repo, _ := newTestUsecase()
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	count, err := usecase.Delete(ctx, models.UserFilter{IDs: []string{user.ID}})
+	if err != nil || count != 1 {
+		t.Fatalf("Delete() = %d, %v, want 1, nil", count, err)
+	}
+
+	if _, err := usecase.GetByID(ctx, user.ID); !errors.Is(err, types.ErrUserNotFound) {
+		t.Errorf("GetByID() deleted user error = %v, want %v", err, types.ErrUserNotFound)
+	}
+
+	if result, _ := usecase.List(ctx, ListFilter{Filters: models.UserFilter{IncludeDeleted: true}}); result.Total != 1 {
+		t.Errorf("List() with deleted total = %d, want 1", result.Total)
+	}
+
+	restored, err := usecase.Restore(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Restore() unexpected error = %v", err)
+	}
+
+	if restored.IsDeleted() {
+		t.Errorf("Restore() user is still deleted")
+	}
+
+	// Удалённый пользователь старше retention удаляется физически.
+	if _, err := usecase.Delete(ctx, models.UserFilter{IDs: []string{user.ID}}); err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+
+	if count, _ := usecase.PurgeDeleted(ctx, time.Hour); count != 0 {
+		t.Errorf("PurgeDeleted() before retention = %d, want 0", count)
+	}
+
+	if count, _ := usecase.PurgeDeleted(ctx, -time.Second); count != 1 {
+		t.Errorf("PurgeDeleted() after retention = %d, want 1", count)
+	}
+
+	if len(repo.users) != 0 {
+		t.Errorf("PurgeDeleted() left %d users", len(repo.users))
+	}
+}
+
+func TestUserUsecase_List_PageToken(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		email := fmt.Sprintf("user%d@example.com", i)
+		if _, err := usecase.Create(ctx, models.CreateUserInput{Email: email, Password: "password123"}); err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	filter := ListFilter{Limit: 2}
+
+	for page := 0; ; page++ {
+		if page > 3 {
+			t.Fatalf("List() did not finish after %d pages", page)
+		}
+
+		result, err := usecase.List(ctx, filter)
+		if err != nil {
+			t.Fatalf("List() unexpected error = %v", err)
+		}
+
+		if result.Total != 5 {
+			t.Errorf("List() total = %d, want 5", result.Total)
+		}
+
+		for _, u := range result.Users {
+			if seen[u.ID] {
+				t.Errorf("List() returned %s twice", u.ID)
+			}
+			seen[u.ID] = true
+		}
+
+		if result.NextPageToken == "" {
+			break
+		}
+
+		filter.PageToken = result.NextPageToken
+	}
+
+	if len(seen) != 5 {
+		t.Errorf("List() returned %d users across pages, want 5", len(seen))
+	}
+
+	_, err := usecase.List(ctx, ListFilter{PageToken: "not-a-token"})
+	if !errors.Is(err, types.ErrInvalidPageToken) {
+		t.Errorf("List() with bad token error = %v, want %v", err, types.ErrInvalidPageToken)
+	}
+}
+
+func TestUserUsecase_List_NegativeOffset(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		email := fmt.Sprintf("user%d@example.com", i)
+		if _, err := usecase.Create(ctx, models.CreateUserInput{Email: email, Password: "password123"}); err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+	}
+
+	result, err := usecase.List(ctx, ListFilter{Offset: -1})
+	if err != nil {
+		t.Fatalf("List() negative offset unexpected error = %v", err)
+	}
+
+	if len(result.Users) != 3 {
+		t.Errorf("List() negative offset returned %d users, want 3", len(result.Users))
+	}
+}
+
+func TestUserUsecase_List_OrderBy(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	for _, name := range []string{"carol", "alice", "bob", "eve", "dave"} {
+		input := models.CreateUserInput{Email: name + "@example.com", Name: name, Password: "password123"}
+		if _, err := usecase.Create(ctx, input); err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+	}
+
+	var names []string
+	filter := ListFilter{Limit: 2, OrderBy: "name"}
+
+	for {
+		result, err := usecase.List(ctx, filter)
+		if err != nil {
+			t.Fatalf("List() unexpected error = %v", err)
+		}
+
+		for _, u := range result.Users {
+			names = append(names, u.Name)
+		}
+
+		if result.NextPageToken == "" {
+			break
+		}
+
+		filter.PageToken = result.NextPageToken
+	}
+
+	if got, want := strings.Join(names, ","), "alice,bob,carol,dave,eve"; got != want {
+		t.Errorf("List() order = %s, want %s", got, want)
+	}
+
+	result, err := usecase.List(ctx, ListFilter{Limit: 2, OrderBy: "name desc"})
+	if err != nil {
+		t.Fatalf("List() unexpected error = %v", err)
+	}
+
+	if result.Users[0].Name != "eve" {
+		t.Errorf("List() desc first = %s, want eve", result.Users[0].Name)
+	}
+
+	// Токен привязан к сортировке, с которой он выдан.
+	_, err = usecase.List(ctx, ListFilter{PageToken: result.NextPageToken, OrderBy: "email"})
+	if !errors.Is(err, types.ErrInvalidPageToken) {
+		t.Errorf("List() with foreign token error = %v, want %v", err, types.ErrInvalidPageToken)
+	}
+
+	for _, orderBy := range []string{"password", "name sideways", "name, name", "name,"} {
+		if _, err := usecase.List(ctx, ListFilter{OrderBy: orderBy}); !errors.Is(err, types.ErrInvalidOrderBy) {
+			t.Errorf("List(%q) error = %v, want %v", orderBy, err, types.ErrInvalidOrderBy)
+		}
+	}
+}
+
+func TestUserUsecase_List_Filters(t *testing.T) {
+	usecase, repo, _ := newTestUsecase()
+	ctx := context.Background()
+
+	for _, email := range []string{"Alice@Acme.com", "bob@acme.com", "carol@example.com", "alina@example.com"} {
+		input := models.CreateUserInput{Email: email, Name: strings.Split(email, "@")[0], Password: "password123"}
+		if _, err := usecase.Create(ctx, input); err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+	}
+
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	for _, u := range repo.users {
+		if u.Email == "bob@acme.com" {
+			u.CreatedAt = weekAgo.Add(-time.Hour)
+			u.Status = types.UserStatusBlocked
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter models.UserFilter
+		want   int
+	}{
+		{name: "email domain", filter: models.UserFilter{EmailDomains: []string{"ACME.com"}}, want: 2},
+		{name: "created last week", filter: models.UserFilter{CreatedAt: models.TimeRange{From: &weekAgo}}, want: 3},
+		{
+			name: "domain and range",
+			filter: models.UserFilter{
+				EmailDomains: []string{"acme.com"},
+				CreatedAt:    models.TimeRange{From: &weekAgo},
+			},
+			want: 1,
+		},
+		{name: "name prefix", filter: models.UserFilter{NameMatch: models.StringMatch{Value: "AL", Prefix: true}}, want: 2},
+		{name: "email contains", filter: models.UserFilter{EmailMatch: models.StringMatch{Value: "ACME"}}, want: 2},
+		{name: "exclude status", filter: models.UserFilter{ExcludeStatuses: []types.UserStatus{types.UserStatusBlocked}}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := usecase.List(ctx, ListFilter{Filters: tt.filter})
+			if err != nil {
+				t.Fatalf("List() unexpected error = %v", err)
+			}
+
+			if result.Total != tt.want || len(result.Users) != tt.want {
+				t.Errorf("List() total = %d, len = %d, want %d", result.Total, len(result.Users), tt.want)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_Search(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+	ctx := context.Background()
+
+	for _, input := range []models.CreateUserInput{
+		{Email: "alice.smith@acme.com", Name: "Alice Smith", Password: "password123"},
+		{Email: "bob@acme.com", Name: "Bob Stone", Password: "password123"},
+		{Email: "carol@example.com", Name: "Carol Smithers", Password: "password123"},
+	} {
+		if _, err := usecase.Create(ctx, input); err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+	}
+
+	result, err := usecase.Search(ctx, SearchFilter{Query: "smith"})
+	if err != nil {
+		t.Fatalf("Search() unexpected error = %v", err)
+	}
+
+	if result.Total != 2 {
+		t.Errorf("Search(smith) total = %d, want 2", result.Total)
+	}
+
+	result, err = usecase.Search(ctx, SearchFilter{Query: "smith acme"})
+	if err != nil {
+		t.Fatalf("Search() unexpected error = %v", err)
+	}
+
+	if result.Total != 1 || result.Users[0].Name != "Alice Smith" {
+		t.Errorf("Search(smith acme) = %d users, want Alice Smith", result.Total)
+	}
+
+	result, err = usecase.Search(ctx, SearchFilter{Query: "smith", Offset: -1})
+	if err != nil {
+		t.Fatalf("Search() negative offset unexpected error = %v", err)
+	}
+
+	if len(result.Users) != 2 {
+		t.Errorf("Search() negative offset returned %d users, want 2", len(result.Users))
+	}
+
+	if _, err := usecase.Search(ctx, SearchFilter{Query: " @ "}); !errors.Is(err, types.ErrEmptySearchQuery) {
+		t.Errorf("Search() empty query error = %v, want %v", err, types.ErrEmptySearchQuery)
+	}
+}
+
+func TestUserUsecase_Authenticate(t *testing.T) {
+	usecase, _, _ := newTestUsecase()
+
+	ctx := context.Background()
+
+	active, err := usecase.Create(ctx, models.CreateUserInput{Email: "active@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	blocked, err := usecase.Create(ctx, models.CreateUserInput{Email: "blocked@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	blocked.Status = types.UserStatusBlocked
+
+	inactive, err := usecase.Create(ctx, models.CreateUserInput{Email: "inactive@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	inactive.Status = types.UserStatusInactive
+
+	tests := []struct {
+		name    string
+		input   models.AuthenticateInput
+		wantErr error
+	}{
+		{
+			name:  "valid credentials",
+			input: models.AuthenticateInput{Email: active.Email, Password: "password123"},
+		},
+		{
+			name:    "wrong password",
+			input:   models.AuthenticateInput{Email: active.Email, Password: "wrong-password"},
+			wantErr: types.ErrInvalidCredentials,
+		},
+		{
+			name:    "unknown email",
+			input:   models.AuthenticateInput{Email: "unknown@example.com", Password: "password123"},
+			wantErr: types.ErrInvalidCredentials,
+		},
+		{
+			name:    "blocked user",
+			input:   models.AuthenticateInput{Email: blocked.Email, Password: "password123"},
+			wantErr: types.ErrUserBlocked,
+		},
+		{
+			name:    "inactive user",
+			input:   models.AuthenticateInput{Email: inactive.Email, Password: "password123"},
+			wantErr: types.ErrUserInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := usecase.Authenticate(ctx, tt.input)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Authenticate() unexpected error = %v", err)
+			}
+
+			if result.User.ID != active.ID {
+				t.Errorf("Authenticate() user = %v, want %v", result.User.ID, active.ID)
+			}
+
+			if result.AccessToken.Token == "" {
+				t.Error("Authenticate() returned empty access token")
+			}
+		})
+	}
+}
+
+// countingHasher запоминает хэши, с которыми сравнивались пароли.
+type countingHasher struct {
+	mockHasher
+	compared []string
+}
+
+func (m *countingHasher) Compare(hash, password string) bool {
+	m.compared = append(m.compared, hash)
+	return m.mockHasher.Compare(hash, password)
+}
+
+func TestUserUsecase_AuthenticateUnknownEmailComparesHash(t *testing.T) {
+	hasher := &countingHasher{}
+	usecase := NewUserUsecase(newMockRepository(), newMockSessionRepository(), hasher, &mockIDGen{}, &mockTokenManager{})
+
+	_, err := usecase.Authenticate(context.Background(), models.AuthenticateInput{Email: "unknown@example.com", Password: "password123"})
+	if !errors.Is(err, types.ErrInvalidCredentials) {
+		t.Fatalf("Authenticate() error = %v, want %v", err, types.ErrInvalidCredentials)
+	}
+
+	if len(hasher.compared) != 1 || hasher.compared[0] != "hashed_"+dummyPassword {
+		t.Errorf("Authenticate() compared hashes = %q, want one dummy hash", hasher.compared)
+	}
+}
+
+func TestUserUsecase_AuthenticateRehash(t *testing.T) {
+	repo := newMockRepository()
+	usecase := NewUserUsecase(repo, newMockSessionRepository(), &upgradingHasher{}, &mockIDGen{}, &mockTokenManager{})
+	ctx := context.Background()
+
+	user, err := usecase.Create(ctx, models.CreateUserInput{Email: "legacy@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	repo.users[user.ID].PasswordHash = "legacy_password123"
+
+	if _, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "wrong"}); !errors.Is(err, types.ErrInvalidCredentials) {
+		t.Fatalf("Authenticate() wrong password error = %v, want %v", err, types.ErrInvalidCredentials)
+	}
+
+	if got := repo.users[user.ID].PasswordHash; got != "legacy_password123" {
+		t.Errorf("PasswordHash after failed login = %q, want unchanged", got)
+	}
+
+	if _, err := usecase.Authenticate(ctx, models.AuthenticateInput{Email: user.Email, Password: "password123"}); err != nil {
+		t.Fatalf("Authenticate() unexpected error = %v", err)
+	}
+
+	if got := repo.users[user.ID].PasswordHash; got != "hashed_password123" {
+		t.Errorf("PasswordHash after login = %q, want rehashed", got)
+	}
+}
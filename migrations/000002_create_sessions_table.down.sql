@@ -0,0 +1,2 @@
+-- Откат миграции: удаление таблицы сессий
+DROP TABLE IF EXISTS sessions;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Откат миграции: удаление версии пользователей
+ALTER TABLE users DROP COLUMN IF EXISTS version;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Версия строки для optimistic locking
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN users.version IS 'Увеличивается при каждом UPDATE, используется как etag';
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Откат миграции: физически удаляем мягко удалённых пользователей
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS idx_users_email;
+CREATE INDEX idx_users_email ON users(email);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
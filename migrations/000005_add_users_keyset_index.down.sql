@@ -0,0 +1,3 @@
+-- Откат миграции: возвращаем индекс только по created_at
+DROP INDEX IF EXISTS idx_users_created_at_id;
+CREATE INDEX idx_users_created_at ON users(created_at DESC);
\ No newline at end of file
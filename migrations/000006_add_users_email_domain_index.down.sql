@@ -0,0 +1,2 @@
+-- Откат миграции: удаление индекса по домену email
+DROP INDEX IF EXISTS idx_users_email_domain;
\ No newline at end of file
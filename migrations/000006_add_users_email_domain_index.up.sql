@@ -0,0 +1,2 @@
+-- Индекс для фильтра по домену email
+CREATE INDEX IF NOT EXISTS idx_users_email_domain ON users (lower(split_part(email, '@', 2)));
\ No newline at end of file
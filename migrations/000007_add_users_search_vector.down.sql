@@ -0,0 +1,3 @@
+-- Откат миграции: удаление полнотекстового поиска
+DROP INDEX IF EXISTS idx_users_search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
\ No newline at end of file
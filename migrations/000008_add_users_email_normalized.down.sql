@@ -0,0 +1,5 @@
+-- Откат миграции: уникальность снова по исходному email
+DROP INDEX IF EXISTS idx_users_email_normalized;
+CREATE UNIQUE INDEX idx_users_email ON users(email) WHERE deleted_at IS NULL;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_normalized;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Каноничная форма email для проверки уникальности без учёта регистра
+-- Для существующих записей берём нижний регистр без пробелов; если после этого
+-- появятся дубликаты, миграция упадёт на создании индекса и их нужно разрешить вручную.
+-- Punycode и правила провайдеров SQL не применяет: после миграции нужно выполнить
+-- `user_service normalize-emails apply`, иначе такие пользователи не смогут войти.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_normalized VARCHAR(255);
+UPDATE users SET email_normalized = lower(trim(email)) WHERE email_normalized IS NULL;
+ALTER TABLE users ALTER COLUMN email_normalized SET NOT NULL;
+
+-- Уникальность переносится с исходного email на каноничный
+DROP INDEX IF EXISTS idx_users_email;
+CREATE UNIQUE INDEX idx_users_email_normalized ON users(email_normalized) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN users.email_normalized IS 'Каноничная форма email, по ней проверяется уникальность'
\ No newline at end of file
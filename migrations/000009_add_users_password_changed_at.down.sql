@@ -0,0 +1,2 @@
+-- Откат миграции: удаление времени смены пароля
+ALTER TABLE users DROP COLUMN IF EXISTS password_changed_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- Время последней смены пароля, для существующих пользователей - время создания
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP WITH TIME ZONE;
+UPDATE users SET password_changed_at = created_at WHERE password_changed_at IS NULL;
+ALTER TABLE users ALTER COLUMN password_changed_at SET NOT NULL;
+
+COMMENT ON COLUMN users.password_changed_at IS 'Время установки текущего пароля'
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Откат миграции: удаление токенов сброса пароля
+DROP TABLE IF EXISTS password_resets;
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- Токены сброса пароля
+CREATE TABLE IF NOT EXISTS password_resets (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Индекс для лимита запросов на пользователя
+CREATE INDEX idx_password_resets_user_id_created_at ON password_resets(user_id, created_at);
+
+-- Комментарии
+COMMENT ON TABLE password_resets IS 'Одноразовые токены сброса пароля';
+COMMENT ON COLUMN password_resets.token_hash IS 'SHA-256 от токена, сам токен не хранится'
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Откат миграции: удаление подтверждения email
+DROP TABLE IF EXISTS email_verifications;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- Подтверждение email
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN users.email_verified_at IS 'Время подтверждения текущего email, NULL если не подтверждён';
+
+-- Токены подтверждения email: текущего адреса после регистрации или нового при смене
+CREATE TABLE IF NOT EXISTS email_verifications (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    email_normalized VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_email_verifications_user_id ON email_verifications(user_id);
+
+COMMENT ON TABLE email_verifications IS 'Одноразовые токены подтверждения email';
+COMMENT ON COLUMN email_verifications.token_hash IS 'SHA-256 от токена, сам токен не хранится'
\ No newline at end of file
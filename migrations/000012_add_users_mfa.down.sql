@@ -0,0 +1,6 @@
+-- Откат миграции: удаление двухфакторной аутентификации
+DROP TABLE IF EXISTS mfa_challenges;
+ALTER TABLE users DROP COLUMN IF EXISTS recovery_code_hashes;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_last_step;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;
\ No newline at end of file
@@ -0,0 +1,27 @@
+-- Двухфакторная аутентификация по TOTP
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_last_step BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS recovery_code_hashes TEXT[];
+
+COMMENT ON COLUMN users.totp_secret IS 'Секрет TOTP, зашифрованный AES-GCM; пустой, если MFA не подключалась';
+COMMENT ON COLUMN users.totp_enabled_at IS 'Время включения MFA, NULL если подключение не подтверждено';
+COMMENT ON COLUMN users.totp_last_step IS 'Временной шаг последнего принятого кода, защита от повторного использования';
+COMMENT ON COLUMN users.recovery_code_hashes IS 'Хэши неиспользованных кодов восстановления';
+
+-- Токены второго шага входа для пользователей с MFA
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_mfa_challenges_user_id ON mfa_challenges(user_id);
+
+COMMENT ON TABLE mfa_challenges IS 'Одноразовые токены второго шага входа';
+COMMENT ON COLUMN mfa_challenges.token_hash IS 'SHA-256 от токена, сам токен не хранится';
+COMMENT ON COLUMN mfa_challenges.attempts IS 'Количество неверных кодов по токену'
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Откат миграции: удаление счётчиков неудачных попыток входа
+DROP TABLE IF EXISTS login_failures;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- Счётчики неудачных попыток входа для защиты от перебора паролей
+CREATE TABLE IF NOT EXISTS login_failures (
+    key VARCHAR(320) PRIMARY KEY,
+    count INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+COMMENT ON TABLE login_failures IS 'Неудачные попытки входа по пользователю и IP';
+COMMENT ON COLUMN login_failures.key IS 'Ключ счётчика: email:<каноничный email> или ip:<адрес>';
+COMMENT ON COLUMN login_failures.last_failure_at IS 'Время последней ошибки, от него отсчитываются задержка и блокировка'
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Откат миграции: удаление ролей пользователей
+DROP TABLE IF EXISTS user_roles;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Роли пользователей для проверки прав доступа
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role)
+);
+
+COMMENT ON TABLE user_roles IS 'Выданные пользователям роли: admin, support, self_service';
+
+-- Существующие пользователи получают доступ к своей записи, как и новые
+INSERT INTO user_roles (user_id, role)
+SELECT id, 'self_service' FROM users
+ON CONFLICT DO NOTHING;
\ No newline at end of file
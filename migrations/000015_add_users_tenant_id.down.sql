@@ -0,0 +1,8 @@
+-- Откат миграции: email снова уникален глобально; если в разных арендаторах
+-- есть одинаковые адреса, миграция упадёт на создании индекса
+DROP INDEX IF EXISTS idx_users_tenant_email_normalized;
+CREATE UNIQUE INDEX idx_users_email_normalized ON users(email_normalized) WHERE deleted_at IS NULL;
+
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+
+COMMENT ON COLUMN login_failures.key IS 'Ключ счётчика: email:<каноничный email> или ip:<адрес>';
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- Арендатор пользователя; существующие записи переносятся в арендатора по умолчанию
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+-- Email уникален в пределах арендатора
+DROP INDEX IF EXISTS idx_users_email_normalized;
+CREATE UNIQUE INDEX idx_users_tenant_email_normalized ON users(tenant_id, email_normalized) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN users.tenant_id IS 'Арендатор, все запросы к пользователям ограничены им';
+
+-- Счётчики неудачных входов пользователей ведутся в пределах арендатора.
+-- Старые ключи email:<email> больше не читаются и истекают сами по окну счётчика.
+COMMENT ON COLUMN login_failures.key IS 'Ключ счётчика: email:<арендатор>:<каноничный email> или ip:<адрес>'
\ No newline at end of file
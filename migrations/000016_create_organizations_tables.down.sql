@@ -0,0 +1,3 @@
+-- Откат миграции: удаление организаций и членства в них
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
\ No newline at end of file
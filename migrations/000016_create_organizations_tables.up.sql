@@ -0,0 +1,25 @@
+-- Организации (группы) пользователей внутри арендатора
+CREATE TABLE IF NOT EXISTS organizations (
+    id VARCHAR(36) PRIMARY KEY,
+    tenant_id VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_organizations_tenant_name ON organizations(tenant_id, name, id);
+
+COMMENT ON TABLE organizations IS 'Организации пользователей, все запросы ограничены арендатором';
+
+-- Членство пользователей в организациях
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id VARCHAR(36) NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+COMMENT ON COLUMN organization_members.role IS 'Роль в организации: owner, admin, member'
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Откат миграции: индекс под keyset в collation базы
+DROP INDEX IF EXISTS idx_users_created_at_id;
+CREATE INDEX idx_users_created_at_id ON users(created_at DESC, id DESC);
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- id замыкает сортировку пользователей и сравнивается с COLLATE "C"
+-- (побайтово, как в in-memory репозитории), индекс под keyset строится так же
+DROP INDEX IF EXISTS idx_users_created_at_id;
+CREATE INDEX idx_users_created_at_id ON users(created_at DESC, id COLLATE "C" DESC);
\ No newline at end of file
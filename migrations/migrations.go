@@ -0,0 +1,9 @@
+// Package migrations встраивает SQL миграции в бинарник.
+package migrations
+
+import "embed"
+
+// FS - файлы миграций вида NNNNNN_name.up.sql / NNNNNN_name.down.sql.
+//
+//go:embed *.sql
+var FS embed.FS
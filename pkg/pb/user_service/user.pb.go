@@ -18,18 +18,60 @@ const (
 	UserStatus_USER_STATUS_ACTIVE      UserStatus = 1
 	UserStatus_USER_STATUS_INACTIVE    UserStatus = 2
 	UserStatus_USER_STATUS_BLOCKED     UserStatus = 3
+	UserStatus_USER_STATUS_DELETED     UserStatus = 4
 )
 
 // User - модель пользователя.
 type User struct {
+	Id                string
+	Email             string
+	Name              string
+	Status            UserStatus
+	CreatedAt         int64
+	UpdatedAt         int64
+	Version           int64
+	DeletedAt         int64
+	PasswordChangedAt int64
+	EmailVerifiedAt   int64
+	MfaEnabled        bool
+	TenantId          string
+}
+
+// Session - активная сессия пользователя.
+type Session struct {
+	Id         string
+	UserId     string
+	UserAgent  string
+	Ip         string
+	CreatedAt  int64
+	LastUsedAt int64
+	ExpiresAt  int64
+}
+
+// Organization - организация (группа) пользователей арендатора.
+type Organization struct {
 	Id        string
-	Email     string
+	TenantId  string
 	Name      string
-	Status    UserStatus
 	CreatedAt int64
 	UpdatedAt int64
 }
 
+// OrganizationMember - членство пользователя в организации.
+type OrganizationMember struct {
+	OrganizationId string
+	UserId         string
+	Role           string
+	CreatedAt      int64
+}
+
+// UserOrganization - организация пользователя и его роль в ней.
+type UserOrganization struct {
+	Organization *Organization
+	Role         string
+	JoinedAt     int64
+}
+
 // CreateUserRequest - запрос на создание пользователя.
 type CreateUserRequest struct {
 	Email    string
@@ -54,10 +96,11 @@ type GetUserResponse struct {
 
 // UpdateUserRequest - запрос на обновление пользователя.
 type UpdateUserRequest struct {
-	Id     string
-	Email  *string
-	Name   *string
-	Status *UserStatus
+	Id      string
+	Email   *string
+	Name    *string
+	Status  *UserStatus
+	Version *int64
 }
 
 // UpdateUserResponse - ответ на обновление пользователя.
@@ -75,24 +118,347 @@ type DeleteUserResponse struct{}
 
 // UserFilter - фильтры для поиска пользователей.
 type UserFilter struct {
-	Ids      []string
-	Emails   []string
-	Statuses []UserStatus
+	Ids             []string
+	Emails          []string
+	Statuses        []UserStatus
+	IncludeDeleted  bool
+	ExcludeStatuses []UserStatus
+	EmailDomains    []string
+	EmailMatch      *StringMatch
+	NameMatch       *StringMatch
+	CreatedFrom     *int64
+	CreatedTo       *int64
+	UpdatedFrom     *int64
+	UpdatedTo       *int64
+}
+
+// StringMatch - регистронезависимый поиск по строке.
+type StringMatch struct {
+	Value  string
+	Prefix bool
 }
 
 // ListUsersRequest - запрос на список пользователей.
 type ListUsersRequest struct {
+	Filter    *UserFilter
+	Limit     int32
+	Offset    int32
+	PageToken string
+	OrderBy   string
+}
+
+// ListUsersResponse - ответ на список пользователей.
+type ListUsersResponse struct {
+	Users         []*User
+	Total         int32
+	NextPageToken string
+}
+
+// AuthenticateRequest - запрос на аутентификацию.
+type AuthenticateRequest struct {
+	Email    string
+	Password string
+}
+
+// AuthenticateResponse - ответ на аутентификацию.
+type AuthenticateResponse struct {
+	User                  *User
+	AccessToken           string
+	AccessTokenExpiresAt  int64
+	RefreshToken          string
+	RefreshTokenExpiresAt int64
+	SessionId             string
+	MfaRequired           bool
+	MfaToken              string
+	MfaTokenExpiresAt     int64
+}
+
+// RefreshTokenRequest - запрос на обновление токенов.
+type RefreshTokenRequest struct {
+	RefreshToken string
+}
+
+// RefreshTokenResponse - ответ на обновление токенов.
+type RefreshTokenResponse struct {
+	AccessToken           string
+	AccessTokenExpiresAt  int64
+	RefreshToken          string
+	RefreshTokenExpiresAt int64
+	SessionId             string
+}
+
+// RevokeSessionRequest - запрос на отзыв сессии.
+type RevokeSessionRequest struct {
+	SessionId string
+}
+
+// RevokeSessionResponse - ответ на отзыв сессии.
+type RevokeSessionResponse struct{}
+
+// RevokeAllSessionsRequest - запрос на отзыв всех сессий пользователя.
+type RevokeAllSessionsRequest struct {
+	UserId string
+}
+
+// RevokeAllSessionsResponse - ответ на отзыв всех сессий пользователя.
+type RevokeAllSessionsResponse struct {
+	Revoked int32
+}
+
+// ListSessionsRequest - запрос на список сессий пользователя.
+type ListSessionsRequest struct {
+	UserId string
+}
+
+// ListSessionsResponse - ответ на список сессий пользователя.
+type ListSessionsResponse struct {
+	Sessions []*Session
+}
+
+// RestoreUserRequest - запрос на восстановление удалённого пользователя.
+type RestoreUserRequest struct {
+	Id string
+}
+
+// RestoreUserResponse - ответ на восстановление пользователя.
+type RestoreUserResponse struct {
+	User *User
+}
+
+// SearchUsersRequest - запрос на полнотекстовый поиск пользователей.
+type SearchUsersRequest struct {
+	Query  string
 	Filter *UserFilter
 	Limit  int32
 	Offset int32
 }
 
-// ListUsersResponse - ответ на список пользователей.
-type ListUsersResponse struct {
+// SearchUsersResponse - ответ на поиск пользователей.
+type SearchUsersResponse struct {
 	Users []*User
 	Total int32
 }
 
+// ChangePasswordRequest - запрос на смену пароля пользователем.
+type ChangePasswordRequest struct {
+	Id             string
+	OldPassword    string
+	NewPassword    string
+	RevokeSessions bool
+}
+
+// ChangePasswordResponse - ответ на смену пароля.
+type ChangePasswordResponse struct {
+	User *User
+}
+
+// SetPasswordRequest - запрос на установку пароля администратором.
+type SetPasswordRequest struct {
+	Id             string
+	Password       string
+	RevokeSessions bool
+}
+
+// SetPasswordResponse - ответ на установку пароля.
+type SetPasswordResponse struct {
+	User *User
+}
+
+// RequestPasswordResetRequest - запрос на сброс пароля.
+type RequestPasswordResetRequest struct {
+	Email string
+}
+
+// RequestPasswordResetResponse - ответ на запрос сброса пароля.
+type RequestPasswordResetResponse struct{}
+
+// ConfirmPasswordResetRequest - запрос на установку пароля по токену сброса.
+type ConfirmPasswordResetRequest struct {
+	Token       string
+	NewPassword string
+}
+
+// ConfirmPasswordResetResponse - ответ на установку пароля по токену сброса.
+type ConfirmPasswordResetResponse struct{}
+
+// VerifyEmailRequest - запрос на подтверждение email.
+type VerifyEmailRequest struct {
+	Token string
+}
+
+// VerifyEmailResponse - ответ на подтверждение email.
+type VerifyEmailResponse struct {
+	User *User
+}
+
+// EnrollTOTPRequest - запрос на подключение TOTP.
+type EnrollTOTPRequest struct {
+	Id string
+}
+
+// EnrollTOTPResponse - ответ на подключение TOTP.
+type EnrollTOTPResponse struct {
+	Secret string
+	Uri    string
+}
+
+// ConfirmTOTPRequest - запрос на подтверждение подключения TOTP.
+type ConfirmTOTPRequest struct {
+	Id   string
+	Code string
+}
+
+// ConfirmTOTPResponse - ответ на подтверждение подключения TOTP.
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string
+}
+
+// DisableTOTPRequest - запрос на отключение TOTP.
+type DisableTOTPRequest struct {
+	Id   string
+	Code string
+}
+
+// DisableTOTPResponse - ответ на отключение TOTP.
+type DisableTOTPResponse struct{}
+
+// VerifyMFARequest - запрос второго шага входа.
+type VerifyMFARequest struct {
+	MfaToken string
+	Code     string
+}
+
+// VerifyMFAResponse - ответ второго шага входа.
+type VerifyMFAResponse struct {
+	User                  *User
+	AccessToken           string
+	AccessTokenExpiresAt  int64
+	RefreshToken          string
+	RefreshTokenExpiresAt int64
+	SessionId             string
+}
+
+// UnlockUserRequest - запрос на снятие блокировки входа пользователя.
+type UnlockUserRequest struct {
+	Id string
+}
+
+// UnlockUserResponse - ответ на снятие блокировки входа пользователя.
+type UnlockUserResponse struct {
+	User *User
+}
+
+// AssignRoleRequest - запрос на выдачу роли пользователю.
+type AssignRoleRequest struct {
+	Id   string
+	Role string
+}
+
+// AssignRoleResponse - роли пользователя после выдачи.
+type AssignRoleResponse struct {
+	Roles []string
+}
+
+// RevokeRoleRequest - запрос на отзыв роли пользователя.
+type RevokeRoleRequest struct {
+	Id   string
+	Role string
+}
+
+// RevokeRoleResponse - роли пользователя после отзыва.
+type RevokeRoleResponse struct {
+	Roles []string
+}
+
+// CreateOrganizationRequest - запрос на создание организации.
+type CreateOrganizationRequest struct {
+	Name string
+}
+
+// CreateOrganizationResponse - ответ на создание организации.
+type CreateOrganizationResponse struct {
+	Organization *Organization
+}
+
+// GetOrganizationRequest - запрос на получение организации.
+type GetOrganizationRequest struct {
+	Id string
+}
+
+// GetOrganizationResponse - ответ на получение организации.
+type GetOrganizationResponse struct {
+	Organization *Organization
+}
+
+// ListOrganizationsRequest - запрос на список организаций.
+type ListOrganizationsRequest struct {
+	Limit  int32
+	Offset int32
+}
+
+// ListOrganizationsResponse - ответ на список организаций.
+type ListOrganizationsResponse struct {
+	Organizations []*Organization
+	Total         int32
+}
+
+// DeleteOrganizationRequest - запрос на удаление организации.
+type DeleteOrganizationRequest struct {
+	Id string
+}
+
+// DeleteOrganizationResponse - ответ на удаление организации.
+type DeleteOrganizationResponse struct{}
+
+// AddOrganizationMemberRequest - запрос на добавление участника организации.
+type AddOrganizationMemberRequest struct {
+	OrganizationId string
+	UserId         string
+	Role           string
+}
+
+// AddOrganizationMemberResponse - ответ на добавление участника организации.
+type AddOrganizationMemberResponse struct {
+	Member *OrganizationMember
+}
+
+// RemoveOrganizationMemberRequest - запрос на исключение участника организации.
+type RemoveOrganizationMemberRequest struct {
+	OrganizationId string
+	UserId         string
+}
+
+// RemoveOrganizationMemberResponse - ответ на исключение участника организации.
+type RemoveOrganizationMemberResponse struct{}
+
+// ListOrganizationMembersRequest - запрос на список участников организации.
+type ListOrganizationMembersRequest struct {
+	OrganizationId string
+}
+
+// ListOrganizationMembersResponse - ответ на список участников организации.
+type ListOrganizationMembersResponse struct {
+	Members []*OrganizationMember
+}
+
+// ListUserOrganizationsRequest - запрос на список организаций пользователя.
+type ListUserOrganizationsRequest struct {
+	UserId string
+}
+
+// ListUserOrganizationsResponse - ответ на список организаций пользователя.
+type ListUserOrganizationsResponse struct {
+	Organizations []*UserOrganization
+}
+
+// ResendEmailVerificationRequest - запрос повторной отправки токена подтверждения email.
+type ResendEmailVerificationRequest struct {
+	Email string
+}
+
+// ResendEmailVerificationResponse - ответ на запрос повторной отправки токена подтверждения email.
+type ResendEmailVerificationResponse struct{}
+
 // UserServiceClient - клиент сервиса.
 type UserServiceClient interface {
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
@@ -100,6 +466,34 @@ type UserServiceClient interface {
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
+	RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest, opts ...grpc.CallOption) (*RevokeAllSessionsResponse, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error)
+	SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	SetPassword(ctx context.Context, in *SetPasswordRequest, opts ...grpc.CallOption) (*SetPasswordResponse, error)
+	RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetResponse, error)
+	ConfirmPasswordReset(ctx context.Context, in *ConfirmPasswordResetRequest, opts ...grpc.CallOption) (*ConfirmPasswordResetResponse, error)
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error)
+	EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPResponse, error)
+	DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*DisableTOTPResponse, error)
+	VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*VerifyMFAResponse, error)
+	UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error)
+	AssignRole(ctx context.Context, in *AssignRoleRequest, opts ...grpc.CallOption) (*AssignRoleResponse, error)
+	RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*RevokeRoleResponse, error)
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error)
+	GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error)
+	ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error)
+	DeleteOrganization(ctx context.Context, in *DeleteOrganizationRequest, opts ...grpc.CallOption) (*DeleteOrganizationResponse, error)
+	AddOrganizationMember(ctx context.Context, in *AddOrganizationMemberRequest, opts ...grpc.CallOption) (*AddOrganizationMemberResponse, error)
+	RemoveOrganizationMember(ctx context.Context, in *RemoveOrganizationMemberRequest, opts ...grpc.CallOption) (*RemoveOrganizationMemberResponse, error)
+	ListOrganizationMembers(ctx context.Context, in *ListOrganizationMembersRequest, opts ...grpc.CallOption) (*ListOrganizationMembersResponse, error)
+	ListUserOrganizations(ctx context.Context, in *ListUserOrganizationsRequest, opts ...grpc.CallOption) (*ListUserOrganizationsResponse, error)
+	ResendEmailVerification(ctx context.Context, in *ResendEmailVerificationRequest, opts ...grpc.CallOption) (*ResendEmailVerificationResponse, error)
 }
 
 // UserServiceServer - серверный интерфейс.
@@ -109,6 +503,34 @@ type UserServiceServer interface {
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error)
+	SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	SetPassword(context.Context, *SetPasswordRequest) (*SetPasswordResponse, error)
+	RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetResponse, error)
+	ConfirmPasswordReset(context.Context, *ConfirmPasswordResetRequest) (*ConfirmPasswordResetResponse, error)
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error)
+	EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPResponse, error)
+	ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error)
+	DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponse, error)
+	VerifyMFA(context.Context, *VerifyMFARequest) (*VerifyMFAResponse, error)
+	UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error)
+	AssignRole(context.Context, *AssignRoleRequest) (*AssignRoleResponse, error)
+	RevokeRole(context.Context, *RevokeRoleRequest) (*RevokeRoleResponse, error)
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	GetOrganization(context.Context, *GetOrganizationRequest) (*GetOrganizationResponse, error)
+	ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error)
+	DeleteOrganization(context.Context, *DeleteOrganizationRequest) (*DeleteOrganizationResponse, error)
+	AddOrganizationMember(context.Context, *AddOrganizationMemberRequest) (*AddOrganizationMemberResponse, error)
+	RemoveOrganizationMember(context.Context, *RemoveOrganizationMemberRequest) (*RemoveOrganizationMemberResponse, error)
+	ListOrganizationMembers(context.Context, *ListOrganizationMembersRequest) (*ListOrganizationMembersResponse, error)
+	ListUserOrganizations(context.Context, *ListUserOrganizationsRequest) (*ListUserOrganizationsResponse, error)
+	ResendEmailVerification(context.Context, *ResendEmailVerificationRequest) (*ResendEmailVerificationResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -130,6 +552,90 @@ func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserReq
 func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
 	return nil, nil
 }
+func (UnimplementedUserServiceServer) Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) SetPassword(context.Context, *SetPasswordRequest) (*SetPasswordResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) ConfirmPasswordReset(context.Context, *ConfirmPasswordResetRequest) (*ConfirmPasswordResetResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) VerifyMFA(context.Context, *VerifyMFARequest) (*VerifyMFAResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) AssignRole(context.Context, *AssignRoleRequest) (*AssignRoleResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) RevokeRole(context.Context, *RevokeRoleRequest) (*RevokeRoleResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) GetOrganization(context.Context, *GetOrganizationRequest) (*GetOrganizationResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) DeleteOrganization(context.Context, *DeleteOrganizationRequest) (*DeleteOrganizationResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) AddOrganizationMember(context.Context, *AddOrganizationMemberRequest) (*AddOrganizationMemberResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) RemoveOrganizationMember(context.Context, *RemoveOrganizationMemberRequest) (*RemoveOrganizationMemberResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) ListOrganizationMembers(context.Context, *ListOrganizationMembersRequest) (*ListOrganizationMembersResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) ListUserOrganizations(context.Context, *ListUserOrganizationsRequest) (*ListUserOrganizationsResponse, error) {
+	return nil, nil
+}
+func (UnimplementedUserServiceServer) ResendEmailVerification(context.Context, *ResendEmailVerificationRequest) (*ResendEmailVerificationResponse, error) {
+	return nil, nil
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // RegisterUserServiceServer регистрирует сервер.
@@ -147,6 +653,34 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 		{MethodName: "UpdateUser"},
 		{MethodName: "DeleteUser"},
 		{MethodName: "ListUsers"},
+		{MethodName: "Authenticate"},
+		{MethodName: "RefreshToken"},
+		{MethodName: "RevokeSession"},
+		{MethodName: "RevokeAllSessions"},
+		{MethodName: "ListSessions"},
+		{MethodName: "RestoreUser"},
+		{MethodName: "SearchUsers"},
+		{MethodName: "ChangePassword"},
+		{MethodName: "SetPassword"},
+		{MethodName: "RequestPasswordReset"},
+		{MethodName: "ConfirmPasswordReset"},
+		{MethodName: "VerifyEmail"},
+		{MethodName: "EnrollTOTP"},
+		{MethodName: "ConfirmTOTP"},
+		{MethodName: "DisableTOTP"},
+		{MethodName: "VerifyMFA"},
+		{MethodName: "UnlockUser"},
+		{MethodName: "AssignRole"},
+		{MethodName: "RevokeRole"},
+		{MethodName: "CreateOrganization"},
+		{MethodName: "GetOrganization"},
+		{MethodName: "ListOrganizations"},
+		{MethodName: "DeleteOrganization"},
+		{MethodName: "AddOrganizationMember"},
+		{MethodName: "RemoveOrganizationMember"},
+		{MethodName: "ListOrganizationMembers"},
+		{MethodName: "ListUserOrganizations"},
+		{MethodName: "ResendEmailVerification"},
 	},
 	Streams: []grpc.StreamDesc{},
 }